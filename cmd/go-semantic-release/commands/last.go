@@ -53,7 +53,7 @@ var lastCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(releaseVersion.Last.Version.String())
+		fmt.Println(releaseVersion.Last.String())
 		return nil
 	},
 }
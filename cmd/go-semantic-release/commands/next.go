@@ -53,7 +53,7 @@ var nextCmd = &cobra.Command{
 		if err != nil {
 			return err
 		}
-		fmt.Println(releaseVersion.Next.Version.String())
+		fmt.Println(releaseVersion.Next.String())
 		return nil
 	},
 }
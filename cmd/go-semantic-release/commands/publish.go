@@ -0,0 +1,40 @@
+package commands
+
+import (
+	"github.com/Nightapes/go-semantic-release/pkg/semanticrelease"
+	"github.com/spf13/cobra"
+)
+
+func init() {
+	publishCmd.Flags().Bool("no-checks", false, "Ignore missing values and envs")
+	rootCmd.AddCommand(publishCmd)
+}
+
+var publishCmd = &cobra.Command{
+	Use:   "publish [version]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Publish a draft release",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		config, err := cmd.Flags().GetString("config")
+		if err != nil {
+			return err
+		}
+
+		repository, err := cmd.Flags().GetString("repository")
+		if err != nil {
+			return err
+		}
+
+		ignoreConfigChecks, err := cmd.Flags().GetBool("no-checks")
+		if err != nil {
+			return err
+		}
+
+		s, err := semanticrelease.New(readConfig(config), repository, !ignoreConfigChecks)
+		if err != nil {
+			return err
+		}
+
+		return s.Publish(args[0])
+	},
+}
@@ -7,6 +7,7 @@ import (
 
 func init() {
 	releaseCmd.Flags().Bool("no-checks", false, "Ignore missing values and envs")
+	releaseCmd.Flags().Bool("dry-run", false, "Print what would be released without changing or releasing anything")
 	rootCmd.AddCommand(releaseCmd)
 }
 
@@ -34,6 +35,11 @@ var releaseCmd = &cobra.Command{
 			return err
 		}
 
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		if err != nil {
+			return err
+		}
+
 		s, err := semanticrelease.New(readConfig(config), repository, !ignoreConfigChecks)
 		if err != nil {
 			return err
@@ -44,6 +50,6 @@ var releaseCmd = &cobra.Command{
 			return err
 		}
 
-		return s.Release(provider, force)
+		return s.Release(provider, force, dryRun)
 	},
 }
@@ -21,6 +21,7 @@ var defaultTokenSeparators = [2]string{": ", " #"}
 // Analyzer struct
 type Analyzer struct {
 	analyzeCommits  analyzeCommits
+	rules           []Rule
 	ChangelogConfig config.ChangelogConfig
 	AnalyzerConfig  config.AnalyzerConfig
 }
@@ -52,15 +53,99 @@ func New(format string, analyzerConfig config.AnalyzerConfig, chglogConfig confi
 	case CONVENTIONAL:
 		analyzer.analyzeCommits = newConventional(analyzerConfig)
 		log.Debugf("Commit format set to %s", CONVENTIONAL)
+	case GITMOJI:
+		analyzer.analyzeCommits = newGitmoji(analyzerConfig)
+		log.Debugf("Commit format set to %s", GITMOJI)
+	case CUSTOM:
+		custom, err := newCustom(analyzerConfig)
+		if err != nil {
+			return nil, err
+		}
+		analyzer.analyzeCommits = custom
+		log.Debugf("Commit format set to %s", CUSTOM)
 	default:
 		return nil, fmt.Errorf("invalid commit format: %s", format)
 	}
+
+	// copy the rules of the format, appending must not change its own slice
+	rules := append([]Rule{}, analyzer.analyzeCommits.getRules()...)
+	rules, err := mergeRules(append(rules, revertRule), analyzerConfig.Rules)
+	if err != nil {
+		return nil, err
+	}
+	analyzer.rules = rules
 	return analyzer, nil
 }
 
 // GetRules from current mode
 func (a *Analyzer) GetRules() []Rule {
-	return a.analyzeCommits.getRules()
+	return a.rules
+}
+
+func (a *Analyzer) getRule(tag string) (Rule, bool) {
+	for _, rule := range a.rules {
+		if rule.Tag == tag {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// mergeRules adds, overrides or removes the default rules with the rules from the config
+func mergeRules(defaults []Rule, customRules []config.AnalyzerRule) ([]Rule, error) {
+	rules := make([]Rule, len(defaults))
+	copy(rules, defaults)
+
+	for _, customRule := range customRules {
+		if customRule.Tag == "" {
+			return nil, fmt.Errorf("analyzer rule without tag")
+		}
+
+		switch customRule.Release {
+		case "", "major", "minor", "patch", "none":
+		default:
+			return nil, fmt.Errorf("invalid release %s for analyzer rule %s, must be one of major, minor, patch or none", customRule.Release, customRule.Tag)
+		}
+
+		index := -1
+		for i, rule := range rules {
+			if rule.Tag == customRule.Tag {
+				index = i
+				break
+			}
+		}
+
+		if customRule.Remove {
+			if index >= 0 {
+				log.Debugf("Remove rule %s", customRule.Tag)
+				rules = append(rules[:index], rules[index+1:]...)
+			}
+			continue
+		}
+
+		if index < 0 {
+			log.Debugf("Add rule %s", customRule.Tag)
+			rules = append(rules, Rule{
+				Tag:       customRule.Tag,
+				TagString: customRule.Tag,
+				Release:   "none",
+			})
+			index = len(rules) - 1
+		} else {
+			log.Debugf("Override rule %s", customRule.Tag)
+		}
+
+		if customRule.TagString != "" {
+			rules[index].TagString = customRule.TagString
+		}
+		if customRule.Release != "" {
+			rules[index].Release = shared.Release(customRule.Release)
+		}
+		if customRule.Changelog != nil {
+			rules[index].Changelog = *customRule.Changelog
+		}
+	}
+	return rules, nil
 }
 
 // Analyze commits and return commits split by major,minor,patch
@@ -71,8 +156,17 @@ func (a *Analyzer) Analyze(commits []shared.Commit) map[shared.Release][]shared.
 	analyzedCommits["patch"] = make([]shared.AnalyzedCommit, 0)
 	analyzedCommits["none"] = make([]shared.AnalyzedCommit, 0)
 
-	for _, commit := range commits {
-		for _, rule := range a.analyzeCommits.getRules() {
+	for _, commit := range filterReverts(commits) {
+		if revert := parseRevert(commit); revert != nil {
+			if rule, ok := a.getRule(REVERT); ok {
+				analyzedCommit := analyzeRevert(commit, revert, rule)
+				analyzedCommit.Print = a.ChangelogConfig.PrintAll || rule.Changelog
+				analyzedCommits[rule.Release] = append(analyzedCommits[rule.Release], *analyzedCommit)
+				continue
+			}
+		}
+
+		for _, rule := range a.rules {
 			analyzedCommit := a.analyzeCommits.analyze(commit, rule)
 			if analyzedCommit == nil {
 				continue
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Nightapes/go-semantic-release/internal/analyzer"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
@@ -14,3 +15,72 @@ func TestAnalyzer(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestAnalyzer_CustomRules(t *testing.T) {
+	changelog := true
+
+	a, err := analyzer.New("angular", config.AnalyzerConfig{
+		Rules: []config.AnalyzerRule{
+			{Tag: "refactor", Release: "patch"},
+			{Tag: "security", TagString: "Security", Release: "patch", Changelog: &changelog},
+			{Tag: "style", Remove: true},
+		},
+	}, config.ChangelogConfig{})
+	assert.NoError(t, err)
+
+	rules := a.GetRules()
+	assert.NotContains(t, rules, analyzer.Rule{Tag: "style", TagString: "Style", Release: "none", Changelog: false})
+	assert.Contains(t, rules, analyzer.Rule{Tag: "refactor", TagString: "Code refactor", Release: "patch", Changelog: false})
+	assert.Equal(t, analyzer.Rule{Tag: "security", TagString: "Security", Release: "patch", Changelog: true}, rules[len(rules)-1])
+
+	analyzedCommits := a.Analyze([]shared.Commit{
+		{Message: "refactor: cleanup", Author: "me", Hash: "1"},
+		{Message: "security(auth): fix token leak", Author: "me", Hash: "2"},
+		{Message: "style: format", Author: "me", Hash: "3"},
+	})
+	assert.Len(t, analyzedCommits["patch"], 2)
+	assert.Equal(t, "Code refactor", analyzedCommits["patch"][0].TagString)
+	assert.False(t, analyzedCommits["patch"][0].Print)
+	assert.Equal(t, "Security", analyzedCommits["patch"][1].TagString)
+	assert.True(t, analyzedCommits["patch"][1].Print)
+	assert.Len(t, analyzedCommits["none"], 0)
+}
+
+func TestAnalyzer_InvalidCustomRules(t *testing.T) {
+	_, err := analyzer.New("angular", config.AnalyzerConfig{
+		Rules: []config.AnalyzerRule{{Tag: "refactor", Release: "huge"}},
+	}, config.ChangelogConfig{})
+	assert.Error(t, err)
+
+	_, err = analyzer.New("conventional", config.AnalyzerConfig{
+		Rules: []config.AnalyzerRule{{TagString: "No tag"}},
+	}, config.ChangelogConfig{})
+	assert.Error(t, err)
+}
+
+func TestAnalyzer_Reverts(t *testing.T) {
+	a, err := analyzer.New("conventional", config.AnalyzerConfig{}, config.ChangelogConfig{})
+	assert.NoError(t, err)
+
+	analyzedCommits := a.Analyze([]shared.Commit{
+		{Message: "feat(x): reverted feature", Author: "me", Hash: "1111111111111111111111111111111111111111"},
+		{Message: "Revert \"feat(x): reverted feature\"\n\nThis reverts commit 1111111111111111111111111111111111111111.\n", Author: "me", Hash: "2222222222222222222222222222222222222222"},
+		{Message: "fix: restored fix", Author: "me", Hash: "3333333333333333333333333333333333333333"},
+		{Message: "revert: fix: restored fix\n\nThis reverts commit 3333333.", Author: "me", Hash: "4444444444444444444444444444444444444444"},
+		{Message: "Revert \"revert: fix: restored fix\"\n\nThis reverts commit 4444444444444444444444444444444444444444.", Author: "me", Hash: "5555555555555555555555555555555555555555"},
+		{Message: "revert(api): feat(api): released feature\n\nThis reverts commit 6666666666666666666666666666666666666666.", Author: "me", Hash: "7777777777777777777777777777777777777777"},
+	})
+
+	assert.Len(t, analyzedCommits["minor"], 0)
+	assert.Len(t, analyzedCommits["major"], 0)
+	assert.Len(t, analyzedCommits["none"], 0)
+	assert.Len(t, analyzedCommits["patch"], 2)
+	assert.Equal(t, "restored fix", analyzedCommits["patch"][0].Subject)
+	assert.Equal(t, "fix", analyzedCommits["patch"][0].Tag)
+
+	assert.Equal(t, "revert", analyzedCommits["patch"][1].Tag)
+	assert.Equal(t, "Reverts", analyzedCommits["patch"][1].TagString)
+	assert.Equal(t, shared.Scope("api"), analyzedCommits["patch"][1].Scope)
+	assert.Equal(t, "feat(api): released feature", analyzedCommits["patch"][1].Subject)
+	assert.True(t, analyzedCommits["patch"][1].Print)
+}
@@ -0,0 +1,120 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+type custom struct {
+	rules            []Rule
+	regex            string
+	breakingKeywords []string
+	log              *log.Entry
+	config           config.AnalyzerConfig
+}
+
+// CUSTOM identifier
+const CUSTOM = "custom"
+
+var customFooterTokenSep = defaultTokenSeparators
+
+// newCustom uses the header regex from the config, the default rules are the same as for conventional
+func newCustom(config config.AnalyzerConfig) (*custom, error) {
+	if err := config.Custom.Validate(); err != nil {
+		return nil, err
+	}
+
+	breakingKeywords := config.Custom.BreakingChangeKeywords
+	if len(breakingKeywords) == 0 {
+		breakingKeywords = []string{breakingChangeKeywords}
+	}
+
+	return &custom{
+		config:           config,
+		regex:            config.Custom.Regex,
+		breakingKeywords: breakingKeywords,
+		log:              log.WithField("analyzer", CUSTOM),
+		rules:            newConventional(config).getRules(),
+	}, nil
+}
+
+func (a *custom) getRules() []Rule {
+	return a.rules
+}
+
+func (a *custom) analyze(commit shared.Commit, rule Rule) *shared.AnalyzedCommit {
+	tokenSep := append(a.config.TokenSeparators, customFooterTokenSep[:]...)
+
+	firstSplit := strings.SplitN(commit.Message, "\n", 2)
+	header := firstSplit[0]
+	body := ""
+	if len(firstSplit) > 1 {
+		body = firstSplit[1]
+	}
+
+	matches := getRegexMatchedMap(a.regex, header)
+
+	if len(matches) == 0 || !strings.EqualFold(matches["type"], rule.Tag) {
+		a.log.Tracef("%s does not match %s, skip", commit.Message, rule.Tag)
+		return nil
+	}
+
+	msgBlockMap := getDefaultMessageBlockMap(body, tokenSep)
+
+	analyzed := &shared.AnalyzedCommit{
+		Commit:        commit,
+		Tag:           rule.Tag,
+		TagString:     rule.TagString,
+		Scope:         shared.Scope(matches["scope"]),
+		Subject:       strings.TrimSpace(matches["subject"]),
+		MessageBlocks: msgBlockMap,
+	}
+
+	// the keyword is only a breaking change as footer token at the start of a body line
+	breakingPrefix := ""
+	for _, line := range strings.Split(body, "\n") {
+		token, _ := findFooterToken(strings.TrimSpace(line), []string{": "})
+		for _, keyword := range a.breakingKeywords {
+			if token == keyword {
+				breakingPrefix = keyword + ":"
+				break
+			}
+		}
+		if breakingPrefix != "" {
+			break
+		}
+	}
+
+	isBreaking := matches["breaking"] != "" || breakingPrefix != ""
+	analyzed.IsBreaking = isBreaking
+
+	oldFormatMessage := strings.TrimSpace(matches["subject"] + "\n" + body)
+
+	if !isBreaking {
+		analyzed.ParsedMessage = strings.Trim(oldFormatMessage, " ")
+		a.log.Tracef("%s: found %s", commit.Message, rule.Tag)
+		return analyzed
+	}
+
+	a.log.Infof(" %s, BREAKING CHANGE found", commit.Message)
+	if breakingPrefix == "" {
+		analyzed.ParsedBreakingChangeMessage = oldFormatMessage
+		return analyzed
+	}
+
+	breakingChange := strings.SplitN(oldFormatMessage, "\n"+breakingPrefix, 2)
+
+	if len(breakingChange) > 1 {
+		analyzed.ParsedMessage = strings.TrimSpace(breakingChange[0])
+		analyzed.ParsedBreakingChangeMessage = strings.TrimSpace(breakingChange[1])
+	} else {
+		analyzed.ParsedBreakingChangeMessage = breakingChange[0]
+	}
+
+	return analyzed
+}
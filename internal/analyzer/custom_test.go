@@ -0,0 +1,168 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/Nightapes/go-semantic-release/internal/analyzer"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCustom(t *testing.T) {
+	t.Parallel()
+	testConfigs := []struct {
+		testCase            string
+		commits             []shared.Commit
+		wantAnalyzedCommits map[shared.Release][]shared.AnalyzedCommit
+	}{
+		{
+			testCase: "feat",
+			wantAnalyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {
+					{
+						Commit: shared.Commit{
+							Message: "[FEAT] JIRA-123: my first commit",
+							Author:  "me",
+							Hash:    "12345667",
+						},
+						Scope:         "JIRA-123",
+						ParsedMessage: "my first commit",
+						Tag:           "feat",
+						TagString:     "Features",
+						Subject:       "my first commit",
+						MessageBlocks: map[string][]shared.MessageBlock{},
+						Print:         true,
+					},
+				},
+				"major": {},
+				"patch": {},
+				"none":  {},
+			},
+			commits: []shared.Commit{
+				{
+					Message: "[FEAT] JIRA-123: my first commit",
+					Author:  "me",
+					Hash:    "12345667",
+				},
+			},
+		},
+		{
+			testCase: "breaking change group and keyword",
+			wantAnalyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {},
+				"major": {
+					{
+						Commit: shared.Commit{
+							Message: "[FEAT!] JIRA-124: my first break",
+							Author:  "me",
+							Hash:    "12345668",
+						},
+						Scope:                       "JIRA-124",
+						Tag:                         "feat",
+						TagString:                   "Features",
+						Print:                       true,
+						ParsedBreakingChangeMessage: "my first break",
+						IsBreaking:                  true,
+						Subject:                     "my first break",
+						MessageBlocks:               map[string][]shared.MessageBlock{},
+					},
+					{
+						Commit: shared.Commit{
+							Message: "[FIX] JIRA-125: my second break\n\nINCOMPATIBLE: change api to v2\n",
+							Author:  "me",
+							Hash:    "12345669",
+						},
+						Scope:                       "JIRA-125",
+						ParsedMessage:               "my second break",
+						Tag:                         "fix",
+						TagString:                   "Bug fixes",
+						Print:                       true,
+						ParsedBreakingChangeMessage: "change api to v2",
+						IsBreaking:                  true,
+						Subject:                     "my second break",
+						MessageBlocks: map[string][]shared.MessageBlock{
+							"footer": {shared.MessageBlock{
+								Label:   "INCOMPATIBLE",
+								Content: "change api to v2",
+							}},
+						},
+					},
+				},
+				"patch": {},
+				"none":  {},
+			},
+			commits: []shared.Commit{
+				{
+					Message: "[FEAT!] JIRA-124: my first break",
+					Author:  "me",
+					Hash:    "12345668",
+				},
+				{
+					Message: "[FIX] JIRA-125: my second break\n\nINCOMPATIBLE: change api to v2\n",
+					Author:  "me",
+					Hash:    "12345669",
+				},
+			},
+		},
+		{
+			testCase: "invalid",
+			wantAnalyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {},
+				"major": {},
+				"patch": {},
+				"none":  {},
+			},
+			commits: []shared.Commit{
+				{
+					Message: "feat(internal/changelog): my first commit",
+					Author:  "me",
+					Hash:    "12345667",
+				},
+			},
+		},
+	}
+
+	custom, err := analyzer.New("custom", config.AnalyzerConfig{
+		TokenSeparators: []string{"#"},
+		Custom: config.AnalyzerCustomFormat{
+			Regex:                  `^\[(?P<type>\w+)(?P<breaking>!)?\] (?P<scope>[A-Z]+-\d+): (?P<subject>.*)`,
+			BreakingChangeKeywords: []string{"INCOMPATIBLE"},
+		},
+	}, config.ChangelogConfig{})
+	assert.NoError(t, err)
+
+	for _, test := range testConfigs {
+		analyzedCommits := custom.Analyze(test.commits)
+		assert.Equalf(t, test.wantAnalyzedCommits["major"], analyzedCommits["major"], "Testcase %s should have major commits", test.testCase)
+		assert.Equalf(t, test.wantAnalyzedCommits["minor"], analyzedCommits["minor"], "Testcase %s should have minor commits", test.testCase)
+		assert.Equalf(t, test.wantAnalyzedCommits["patch"], analyzedCommits["patch"], "Testcase %s should have patch commits", test.testCase)
+		assert.Equalf(t, test.wantAnalyzedCommits["none"], analyzedCommits["none"], "Testcase %s should have none commits", test.testCase)
+	}
+}
+
+func TestCustom_InvalidConfig(t *testing.T) {
+	for _, regex := range []string{"", `^(?P<type>\w+`, `^(?P<type>\w+): .*`} {
+		_, err := analyzer.New("custom", config.AnalyzerConfig{
+			Custom: config.AnalyzerCustomFormat{Regex: regex},
+		}, config.ChangelogConfig{})
+		assert.Errorf(t, err, "regex %s should be invalid", regex)
+	}
+}
+
+func TestCustom_BreakingKeywordInText(t *testing.T) {
+	custom, err := analyzer.New("custom", config.AnalyzerConfig{
+		Custom: config.AnalyzerCustomFormat{
+			Regex:                  `^\[(?P<type>\w+)(?P<breaking>!)?\] (?P<scope>[A-Z]+-\d+): (?P<subject>.*)$`,
+			BreakingChangeKeywords: []string{"INCOMPATIBLE"},
+		},
+	}, config.ChangelogConfig{})
+	assert.NoError(t, err)
+
+	analyzedCommits := custom.Analyze([]shared.Commit{
+		{Message: "[FIX] JIRA-126: handle INCOMPATIBLE: responses\n\nThe server answers with INCOMPATIBLE: for old clients", Hash: "1"},
+	})
+	assert.Empty(t, analyzedCommits["major"])
+	assert.Len(t, analyzedCommits["patch"], 1)
+	assert.False(t, analyzedCommits["patch"][0].IsBreaking)
+}
@@ -0,0 +1,282 @@
+package analyzer
+
+import (
+	"strings"
+
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+type gitmoji struct {
+	rules  []Rule
+	emojis map[string]string
+	regex  string
+	log    *log.Entry
+	config config.AnalyzerConfig
+}
+
+// GITMOJI identifier
+const GITMOJI = "gitmoji"
+
+const gitmojiBreakingTag = "boom"
+const variationSelector = "\uFE0F"
+
+var gitmojiFooterTokenSep = defaultTokenSeparators
+
+func newGitmoji(config config.AnalyzerConfig) *gitmoji {
+	return &gitmoji{
+		config: config,
+		regex:  `^:(?P<type>[\w+-]+):\s*(?:\((?P<scope>[^)]*)\))?:?\s*(?P<subject>.*)`,
+		log:    log.WithField("analyzer", GITMOJI),
+		emojis: map[string]string{
+			"\U0001F4A5": "boom",
+			"\u2728":     "sparkles",
+			"\U0001F41B": "bug",
+			"\U0001F691": "ambulance",
+			"\U0001FA79": "adhesive_bandage",
+			"\U0001F512": "lock",
+			"\u26A1":     "zap",
+			"\U0001F484": "lipstick",
+			"\u2B06":     "arrow_up",
+			"\u2B07":     "arrow_down",
+			"\U0001F4CC": "pushpin",
+			"\U0001F310": "globe_with_meridians",
+			"\u270F":     "pencil2",
+			"\u267F":     "wheelchair",
+			"\U0001F4DD": "memo",
+			"\U0001F3A8": "art",
+			"\u267B":     "recycle",
+			"\u2705":     "white_check_mark",
+			"\U0001F477": "construction_worker",
+			"\U0001F49A": "green_heart",
+			"\U0001F527": "wrench",
+			"\U0001F525": "fire",
+			"\U0001F6A8": "rotating_light",
+			"\U0001F69A": "truck",
+			"\U0001F516": "bookmark",
+		},
+		rules: []Rule{
+			{
+				Tag:       "boom",
+				TagString: "Breaking changes",
+				Release:   "major",
+				Changelog: true,
+			},
+			{
+				Tag:       "sparkles",
+				TagString: "Features",
+				Release:   "minor",
+				Changelog: true,
+			},
+			{
+				Tag:       "bug",
+				TagString: "Bug fixes",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "ambulance",
+				TagString: "Bug fixes",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "adhesive_bandage",
+				TagString: "Bug fixes",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "lock",
+				TagString: "Security",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "zap",
+				TagString: "Performance improvements",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "lipstick",
+				TagString: "UI and style",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "globe_with_meridians",
+				TagString: "Internationalization",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "pencil2",
+				TagString: "Typos",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "wheelchair",
+				TagString: "Accessibility",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "arrow_up",
+				TagString: "Dependencies",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "arrow_down",
+				TagString: "Dependencies",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "pushpin",
+				TagString: "Dependencies",
+				Release:   "patch",
+				Changelog: true,
+			},
+			{
+				Tag:       "memo",
+				TagString: "Documentation changes",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "art",
+				TagString: "Style",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "recycle",
+				TagString: "Code refactor",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "fire",
+				TagString: "Code refactor",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "truck",
+				TagString: "Code refactor",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "white_check_mark",
+				TagString: "Testing",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "rotating_light",
+				TagString: "Testing",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "wrench",
+				TagString: "Changes to the build process or auxiliary tools and libraries such as documentation generation",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "construction_worker",
+				TagString: "Changes to CI/CD",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "green_heart",
+				TagString: "Changes to CI/CD",
+				Release:   "none",
+				Changelog: false,
+			},
+			{
+				Tag:       "bookmark",
+				TagString: "Release",
+				Release:   "none",
+				Changelog: false,
+			},
+		},
+	}
+}
+
+func (a *gitmoji) getRules() []Rule {
+	return a.rules
+}
+
+// normalizeHeader replaces a leading unicode emoji with its :shortcode:
+func (a *gitmoji) normalizeHeader(header string) string {
+	header = strings.TrimSpace(header)
+	for emoji, code := range a.emojis {
+		if strings.HasPrefix(header, emoji) {
+			rest := strings.TrimPrefix(strings.TrimPrefix(header, emoji), variationSelector)
+			return ":" + code + ": " + strings.TrimSpace(rest)
+		}
+	}
+	return header
+}
+
+func (a *gitmoji) analyze(commit shared.Commit, rule Rule) *shared.AnalyzedCommit {
+	tokenSep := append(a.config.TokenSeparators, gitmojiFooterTokenSep[:]...)
+
+	firstSplit := strings.SplitN(commit.Message, "\n", 2)
+	header := a.normalizeHeader(firstSplit[0])
+	body := ""
+	if len(firstSplit) > 1 {
+		body = firstSplit[1]
+	}
+
+	matches := getRegexMatchedMap(a.regex, header)
+
+	if len(matches) == 0 || matches["type"] != rule.Tag {
+		a.log.Tracef("%s does not match %s, skip", commit.Message, rule.Tag)
+		return nil
+	}
+
+	msgBlockMap := getDefaultMessageBlockMap(body, tokenSep)
+
+	analyzed := &shared.AnalyzedCommit{
+		Commit:        commit,
+		Tag:           rule.Tag,
+		TagString:     rule.TagString,
+		Scope:         shared.Scope(matches["scope"]),
+		Subject:       strings.TrimSpace(matches["subject"]),
+		MessageBlocks: msgBlockMap,
+	}
+
+	isBreaking := rule.Tag == gitmojiBreakingTag || strings.Contains(commit.Message, defaultBreakingChangePrefix)
+	analyzed.IsBreaking = isBreaking
+
+	oldFormatMessage := strings.TrimSpace(matches["subject"] + "\n" + body)
+
+	if !isBreaking {
+		analyzed.ParsedMessage = strings.Trim(oldFormatMessage, " ")
+		a.log.Tracef("%s: found %s", commit.Message, rule.Tag)
+		return analyzed
+	}
+
+	a.log.Infof(" %s, BREAKING CHANGE found", commit.Message)
+	breakingChange := strings.SplitN(oldFormatMessage, defaultBreakingChangePrefix, 2)
+
+	if len(breakingChange) > 1 {
+		analyzed.ParsedMessage = strings.TrimSpace(breakingChange[0])
+		analyzed.ParsedBreakingChangeMessage = strings.TrimSpace(breakingChange[1])
+	} else {
+		analyzed.ParsedBreakingChangeMessage = breakingChange[0]
+	}
+
+	return analyzed
+}
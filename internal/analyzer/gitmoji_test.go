@@ -0,0 +1,174 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/Nightapes/go-semantic-release/internal/analyzer"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitmoji(t *testing.T) {
+	t.Parallel()
+	testConfigs := []struct {
+		testCase            string
+		commits             []shared.Commit
+		wantAnalyzedCommits map[shared.Release][]shared.AnalyzedCommit
+	}{
+		{
+			testCase: "sparkles as emoji and shortcode",
+			wantAnalyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {
+					{
+						Commit: shared.Commit{
+							Message: "✨ (login): add login page",
+							Author:  "me",
+							Hash:    "12345667",
+						},
+						Scope:         "login",
+						ParsedMessage: "add login page",
+						Tag:           "sparkles",
+						TagString:     "Features",
+						Subject:       "add login page",
+						MessageBlocks: map[string][]shared.MessageBlock{},
+						Print:         true,
+					},
+					{
+						Commit: shared.Commit{
+							Message: ":sparkles: add logout",
+							Author:  "me",
+							Hash:    "12345668",
+						},
+						Scope:         "",
+						ParsedMessage: "add logout",
+						Tag:           "sparkles",
+						TagString:     "Features",
+						Subject:       "add logout",
+						MessageBlocks: map[string][]shared.MessageBlock{},
+						Print:         true,
+					},
+				},
+				"major": {},
+				"patch": {},
+				"none":  {},
+			},
+			commits: []shared.Commit{
+				{
+					Message: "✨ (login): add login page",
+					Author:  "me",
+					Hash:    "12345667",
+				},
+				{
+					Message: ":sparkles: add logout",
+					Author:  "me",
+					Hash:    "12345668",
+				},
+			},
+		},
+		{
+			testCase: "bug, boom and memo",
+			wantAnalyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {},
+				"major": {
+					{
+						Commit: shared.Commit{
+							Message: "💥 remove v1 api",
+							Author:  "me",
+							Hash:    "12345669",
+						},
+						Scope:                       "",
+						Tag:                         "boom",
+						TagString:                   "Breaking changes",
+						Subject:                     "remove v1 api",
+						ParsedBreakingChangeMessage: "remove v1 api",
+						IsBreaking:                  true,
+						MessageBlocks:               map[string][]shared.MessageBlock{},
+						Print:                       true,
+					},
+				},
+				"patch": {
+					{
+						Commit: shared.Commit{
+							Message: "🚑️ fix crash on startup",
+							Author:  "me",
+							Hash:    "12345670",
+						},
+						Scope:         "",
+						ParsedMessage: "fix crash on startup",
+						Tag:           "ambulance",
+						TagString:     "Bug fixes",
+						Subject:       "fix crash on startup",
+						MessageBlocks: map[string][]shared.MessageBlock{},
+						Print:         true,
+					},
+				},
+				"none": {
+					{
+						Commit: shared.Commit{
+							Message: ":memo: update readme",
+							Author:  "me",
+							Hash:    "12345671",
+						},
+						Scope:         "",
+						ParsedMessage: "update readme",
+						Tag:           "memo",
+						TagString:     "Documentation changes",
+						Subject:       "update readme",
+						MessageBlocks: map[string][]shared.MessageBlock{},
+						Print:         false,
+					},
+				},
+			},
+			commits: []shared.Commit{
+				{
+					Message: "💥 remove v1 api",
+					Author:  "me",
+					Hash:    "12345669",
+				},
+				{
+					Message: "🚑️ fix crash on startup",
+					Author:  "me",
+					Hash:    "12345670",
+				},
+				{
+					Message: ":memo: update readme",
+					Author:  "me",
+					Hash:    "12345671",
+				},
+			},
+		},
+		{
+			testCase: "invalid",
+			wantAnalyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {},
+				"major": {},
+				"patch": {},
+				"none":  {},
+			},
+			commits: []shared.Commit{
+				{
+					Message: "feat: my first commit",
+					Author:  "me",
+					Hash:    "12345667",
+				},
+				{
+					Message: ":unknown: my first commit",
+					Author:  "me",
+					Hash:    "12345668",
+				},
+			},
+		},
+	}
+
+	gitmoji, err := analyzer.New("gitmoji", config.AnalyzerConfig{}, config.ChangelogConfig{})
+	assert.NoError(t, err)
+
+	for _, test := range testConfigs {
+		analyzedCommits := gitmoji.Analyze(test.commits)
+		assert.Equalf(t, test.wantAnalyzedCommits["major"], analyzedCommits["major"], "Testcase %s should have major commits", test.testCase)
+		assert.Equalf(t, test.wantAnalyzedCommits["minor"], analyzedCommits["minor"], "Testcase %s should have minor commits", test.testCase)
+		assert.Equalf(t, test.wantAnalyzedCommits["patch"], analyzedCommits["patch"], "Testcase %s should have patch commits", test.testCase)
+		assert.Equalf(t, test.wantAnalyzedCommits["none"], analyzedCommits["none"], "Testcase %s should have none commits", test.testCase)
+	}
+}
@@ -0,0 +1,56 @@
+package analyzer
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// referenceRegex matches issues (#123) and merge requests (!123) of the same project,
+// references to other projects like group/project#123 are ignored
+var referenceRegex = regexp.MustCompile(`(?:^|[\s(,;]|merge request \S+)([#!])(\d+)\b`)
+
+// footerReferenceRegex matches footers with the " #" separator like "Closes #45", the separator is not part of the parsed footer
+var footerReferenceRegex = regexp.MustCompile(`(?m)^(?:[\w-]+|BREAKING CHANGE) (#)(\d+)\b`)
+
+// References returns the issues and merge requests referenced in the subject, body or footers of the commits
+func References(commits map[shared.Release][]shared.AnalyzedCommit) []shared.Reference {
+	found := map[shared.Reference]bool{}
+	for _, analyzedCommits := range commits {
+		for _, commit := range analyzedCommits {
+			texts := []string{commit.Subject}
+			for _, block := range commit.MessageBlocks["body"] {
+				texts = append(texts, block.Content)
+			}
+			for _, block := range commit.MessageBlocks["footer"] {
+				texts = append(texts, block.Content)
+			}
+
+			matches := footerReferenceRegex.FindAllStringSubmatch(commit.Commit.Message, -1)
+			for _, text := range texts {
+				matches = append(matches, referenceRegex.FindAllStringSubmatch(text, -1)...)
+			}
+			for _, match := range matches {
+				number, err := strconv.Atoi(match[2])
+				if err != nil {
+					continue
+				}
+				found[shared.Reference{Number: number, MergeRequest: match[1] == "!"}] = true
+			}
+		}
+	}
+
+	references := make([]shared.Reference, 0, len(found))
+	for reference := range found {
+		references = append(references, reference)
+	}
+	sort.Slice(references, func(i, j int) bool {
+		if references[i].MergeRequest != references[j].MergeRequest {
+			return !references[i].MergeRequest
+		}
+		return references[i].Number < references[j].Number
+	})
+	return references
+}
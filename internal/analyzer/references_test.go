@@ -0,0 +1,36 @@
+package analyzer_test
+
+import (
+	"testing"
+
+	"github.com/Nightapes/go-semantic-release/internal/analyzer"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferences(t *testing.T) {
+	a, err := analyzer.New("conventional", config.AnalyzerConfig{}, config.ChangelogConfig{})
+	assert.NoError(t, err)
+
+	commits := a.Analyze([]shared.Commit{
+		{Message: "feat: add login (#12)", Hash: "1"},
+		{Message: "fix: crash on start\n\nCloses #45", Hash: "2"},
+		{Message: "fix: typo\n\nSee merge request group/project!7\n\nRefs: #3, #12, other/repo#99", Hash: "3"},
+		{Message: "feat!: breaking api", Hash: "4"},
+		{Message: "fix: parser\n\nFixes #8", Hash: "6"},
+	})
+
+	assert.Equal(t, []shared.Reference{
+		{Number: 3},
+		{Number: 8},
+		{Number: 12},
+		{Number: 45},
+		{Number: 7, MergeRequest: true},
+	}, analyzer.References(commits))
+
+	commits = a.Analyze([]shared.Commit{
+		{Message: "feat: new config\n\nBREAKING CHANGE: 3 options are removed", Hash: "5"},
+	})
+	assert.Empty(t, analyzer.References(commits))
+}
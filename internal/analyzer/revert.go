@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	log "github.com/sirupsen/logrus"
+)
+
+// REVERT tag for revert commits
+const REVERT = "revert"
+
+var revertRule = Rule{
+	Tag:       REVERT,
+	TagString: "Reverts",
+	Release:   "patch",
+	Changelog: true,
+}
+
+var revertHeaderRegex = regexp.MustCompile(`^(?:Revert "(?P<gitSubject>.*)"|(?i:revert)(?:\((?P<scope>[^)]*)\))?!?: (?P<subject>.*))$`)
+var revertHashRegex = regexp.MustCompile(`This reverts commit (?P<hash>[0-9a-fA-F]{7,40})`)
+
+type revert struct {
+	scope   string
+	subject string
+	hash    string
+}
+
+// parseRevert returns the revert infos if the commit reverts another commit, nil otherwise
+func parseRevert(commit shared.Commit) *revert {
+	firstSplit := strings.SplitN(commit.Message, "\n", 2)
+	header := strings.TrimSpace(firstSplit[0])
+
+	matches := revertHeaderRegex.FindStringSubmatch(header)
+	if matches == nil {
+		return nil
+	}
+
+	r := &revert{
+		scope:   matches[revertHeaderRegex.SubexpIndex("scope")],
+		subject: matches[revertHeaderRegex.SubexpIndex("gitSubject")] + matches[revertHeaderRegex.SubexpIndex("subject")],
+	}
+
+	if len(firstSplit) > 1 {
+		if hash := revertHashRegex.FindStringSubmatch(firstSplit[1]); hash != nil {
+			r.hash = strings.ToLower(hash[revertHashRegex.SubexpIndex("hash")])
+		}
+	}
+	return r
+}
+
+// filterReverts removes commits which are reverted in the same range together with their reverting commits.
+// A revert of a revert restores the original commit.
+func filterReverts(commits []shared.Commit) []shared.Commit {
+	reverts := map[string]*revert{}
+	for _, commit := range commits {
+		if r := parseRevert(commit); r != nil && r.hash != "" {
+			reverts[commit.Hash] = r
+		}
+	}
+	if len(reverts) == 0 {
+		return commits
+	}
+
+	findCommit := func(hash string) string {
+		for _, commit := range commits {
+			if commit.Hash != "" && (strings.HasPrefix(commit.Hash, hash) || strings.HasPrefix(hash, commit.Hash)) {
+				return commit.Hash
+			}
+		}
+		return ""
+	}
+
+	// a commit is cancelled if a revert in the range, which is not cancelled itself, points to it
+	var isCancelled func(hash string, seen map[string]bool) bool
+	isCancelled = func(hash string, seen map[string]bool) bool {
+		if seen[hash] {
+			return false
+		}
+		seen[hash] = true
+		for revertHash, r := range reverts {
+			if findCommit(r.hash) == hash && !isCancelled(revertHash, seen) {
+				return true
+			}
+		}
+		return false
+	}
+
+	dropped := map[string]bool{}
+	for _, commit := range commits {
+		if isCancelled(commit.Hash, map[string]bool{}) {
+			dropped[commit.Hash] = true
+		}
+	}
+
+	// reverts pointing into the range are either part of a dropped pair or cancelled themselves
+	for revertHash, r := range reverts {
+		if findCommit(r.hash) != "" {
+			dropped[revertHash] = true
+		}
+	}
+
+	filtered := make([]shared.Commit, 0, len(commits))
+	for _, commit := range commits {
+		if dropped[commit.Hash] {
+			log.Debugf("Commit %s was reverted in the same range, skip", commit.Hash)
+			continue
+		}
+		filtered = append(filtered, commit)
+	}
+	return filtered
+}
+
+// analyzeRevert creates an analyzed commit for a revert of an already released commit
+func analyzeRevert(commit shared.Commit, r *revert, rule Rule) *shared.AnalyzedCommit {
+	body := ""
+	if firstSplit := strings.SplitN(commit.Message, "\n", 2); len(firstSplit) > 1 {
+		body = firstSplit[1]
+	}
+
+	return &shared.AnalyzedCommit{
+		Commit:        commit,
+		Tag:           rule.Tag,
+		TagString:     rule.TagString,
+		Scope:         shared.Scope(r.scope),
+		Subject:       strings.TrimSpace(r.subject),
+		ParsedMessage: strings.TrimSpace(r.subject + "\n" + body),
+		MessageBlocks: getDefaultMessageBlockMap(body, defaultTokenSeparators[:]),
+	}
+}
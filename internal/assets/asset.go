@@ -28,6 +28,8 @@ type Asset struct {
 	zippedPath   string
 	algorithm    string
 	isCompressed bool
+	// dryRun assets are never zipped
+	dryRun bool
 }
 
 //NewAsset from a config
@@ -64,17 +66,8 @@ func NewAsset(repository string, assetConfig config.Asset, algorithm string) (*A
 	return asset, nil
 }
 
-func (a *Asset) getChecksum() (string, error) {
-	path, err := a.GetPath()
-	if err != nil {
-		return "", nil
-	}
-	log.Debugf("Calculating checksum for %s", path)
-	file, err := os.Open(path)
-	if err != nil {
-		return "", errors.Wrapf(err, "Failed to open file %s to calculate checksum", a.name)
-	}
-	defer file.Close() // nolint: errcheck
+// GetChecksum of the asset, compressed assets are zipped into the hash without writing the zip file
+func (a *Asset) GetChecksum() (string, error) {
 	var hash hash.Hash
 	switch a.algorithm {
 	case "crc32":
@@ -94,6 +87,25 @@ func (a *Asset) getChecksum() (string, error) {
 	default:
 		hash = sha256.New()
 	}
+
+	if a.isCompressed && a.zippedPath == "" {
+		log.Debugf("Calculating checksum for zipped %s", a.path)
+		if err := a.writeZip(hash); err != nil {
+			return "", err
+		}
+		return hex.EncodeToString(hash.Sum(nil)), nil
+	}
+
+	path := a.path
+	if a.isCompressed {
+		path = a.zippedPath
+	}
+	log.Debugf("Calculating checksum for %s", path)
+	file, err := os.Open(path)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to open file %s to calculate checksum", a.name)
+	}
+	defer file.Close() // nolint: errcheck
 	_, err = io.Copy(hash, file)
 	if err != nil {
 		return "", err
@@ -103,6 +115,10 @@ func (a *Asset) getChecksum() (string, error) {
 
 // GetPath where the file is located, if zipped true, it will compress it and give you the new location
 func (a *Asset) GetPath() (string, error) {
+	if a.isCompressed && a.dryRun {
+		log.Debugf("Dry run, %s is not zipped", a.path)
+		return a.path, nil
+	}
 	if a.isCompressed {
 		return a.ZipFile()
 	}
@@ -129,13 +145,6 @@ func (a *Asset) ZipFile() (string, error) {
 		return a.zippedPath, nil
 	}
 
-	path := a.path
-	fileToZip, err := os.Open(path)
-	if err != nil {
-		return "", errors.Wrapf(err, "Could not open file %s", path)
-	}
-	defer fileToZip.Close()
-
 	zipFile, err := ioutil.TempFile(os.TempDir(), "asset.*.zip")
 
 	if err != nil {
@@ -143,37 +152,53 @@ func (a *Asset) ZipFile() (string, error) {
 	}
 	log.Debugf("Created zipfile %s", zipFile.Name())
 
+	if err := a.writeZip(zipFile); err != nil {
+		zipFile.Close()
+		return "", err
+	}
+
+	if err := zipFile.Close(); err != nil {
+		return "", errors.Wrap(err, "Could not close file")
+	}
+	a.zippedPath, err = filepath.Abs(zipFile.Name())
+
+	return a.zippedPath, err
+}
+
+// writeZip of the file to the writer
+func (a *Asset) writeZip(w io.Writer) error {
+	path := a.path
+	fileToZip, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "Could not open file %s", path)
+	}
+	defer fileToZip.Close()
+
 	fileToZipInfo, err := fileToZip.Stat()
 	if err != nil {
-		return "", errors.Wrap(err, "Could not read file infos")
+		return errors.Wrap(err, "Could not read file infos")
 	}
 
-	zipWriter := zip.NewWriter(zipFile)
+	zipWriter := zip.NewWriter(w)
 
 	fileToZipHeader, err := zip.FileInfoHeader(fileToZipInfo)
 	if err != nil {
-		return "", errors.Wrap(err, "Could not add file infos to zip handler")
+		return errors.Wrap(err, "Could not add file infos to zip handler")
 	}
 
 	fileToZipHeader.Name = fileToZipInfo.Name()
 
 	fileToZipWriter, err := zipWriter.CreateHeader(fileToZipHeader)
 	if err != nil {
-		return "", errors.Wrap(err, "Could not create zip header")
+		return errors.Wrap(err, "Could not create zip header")
 	}
 
 	if _, err = io.Copy(fileToZipWriter, fileToZip); err != nil {
-		return "", errors.Wrap(err, "Could not zip file")
+		return errors.Wrap(err, "Could not zip file")
 	}
 
 	if err := zipWriter.Close(); err != nil {
-		return "", errors.Wrap(err, fmt.Sprintf("Could not close zipwriter for zip %s", a.path))
-	}
-
-	if err := zipFile.Close(); err != nil {
-		return "", errors.Wrap(err, "Could not close file")
+		return errors.Wrap(err, fmt.Sprintf("Could not close zipwriter for zip %s", a.path))
 	}
-	a.zippedPath, err = filepath.Abs(zipFile.Name())
-
-	return a.zippedPath, err
+	return nil
 }
@@ -0,0 +1,46 @@
+package assets_test
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAsset_GetChecksum(t *testing.T) {
+	repository := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(repository, "app"), []byte("binary"), 0644))
+
+	set := assets.New(repository, "sha256")
+	assert.NoError(t, set.Add(config.Asset{Path: "app", Compress: true}))
+	set.DryRun()
+	asset := set.All()[0]
+
+	checksum, err := asset.GetChecksum()
+	assert.NoError(t, err)
+	path, err := asset.GetPath()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(repository, "app"), path, "dry run must not zip the file")
+
+	zipped, err := asset.ZipFile()
+	assert.NoError(t, err)
+	defer os.Remove(zipped)
+	content, err := os.ReadFile(zipped)
+	assert.NoError(t, err)
+	sum := sha256.Sum256(content)
+	assert.Equal(t, hex.EncodeToString(sum[:]), checksum)
+
+	zippedChecksum, err := asset.GetChecksum()
+	assert.NoError(t, err)
+	assert.Equal(t, checksum, zippedChecksum)
+
+	assert.NoError(t, os.Remove(filepath.Join(repository, "app")))
+	assert.NoError(t, os.Remove(zipped))
+	_, err = asset.GetChecksum()
+	assert.Error(t, err)
+}
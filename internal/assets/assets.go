@@ -39,6 +39,13 @@ func (s *Set) Add(assetConfigs ...config.Asset) error {
 	return nil
 }
 
+// DryRun keeps the files untouched, compressed assets are not zipped
+func (s *Set) DryRun() {
+	for _, asset := range s.assets {
+		asset.dryRun = true
+	}
+}
+
 func (s *Set) All() []*Asset {
 	return s.assets
 }
@@ -51,7 +58,7 @@ func (s *Set) GenerateChecksum() error {
 	defer checksumFile.Close()
 	lines := []string{}
 	for _, asset := range s.assets {
-		checksum, err := asset.getChecksum()
+		checksum, err := asset.GetChecksum()
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,93 @@
+// Package branch resolves the release type for a git branch from the branch config
+package branch
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const wildcard = "*"
+
+var invalidIdentifierChars = regexp.MustCompile(`[^0-9A-Za-z-]+`)
+
+// Match result for a branch
+type Match struct {
+	// Pattern from the branch config which matched the branch
+	Pattern string
+	// Wildcard part of the branch name, sanitized to be used in a prerelease identifier
+	Wildcard string
+	// ReleaseType with replaced wildcard, like beta or beta.payments
+	ReleaseType string
+}
+
+// Find the matching branch config for a branch.
+// Exact matches beat glob patterns, if several glob patterns match, the longest pattern wins.
+func Find(branches map[string]string, name string) (*Match, bool) {
+	if releaseType, ok := branches[name]; ok {
+		log.Debugf("Found exact branch config for branch %s with release type %s", name, releaseType)
+		return &Match{
+			Pattern:     name,
+			ReleaseType: replaceWildcard(releaseType, ""),
+		}, true
+	}
+
+	patterns := make([]string, 0)
+	for pattern := range branches {
+		if strings.Contains(pattern, wildcard) {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if len(patterns[i]) == len(patterns[j]) {
+			return patterns[i] < patterns[j]
+		}
+		return len(patterns[i]) > len(patterns[j])
+	})
+
+	for _, pattern := range patterns {
+		parts, ok := matchGlob(pattern, name)
+		if !ok {
+			continue
+		}
+		match := &Match{
+			Pattern:  pattern,
+			Wildcard: sanitize(strings.Join(parts, "-")),
+		}
+		match.ReleaseType = replaceWildcard(branches[pattern], match.Wildcard)
+		log.Debugf("Found branch config %s for branch %s with release type %s", pattern, name, match.ReleaseType)
+		return match, true
+	}
+	return nil, false
+}
+
+// matchGlob checks if name matches the pattern and returns the parts matched by the wildcards
+func matchGlob(pattern, name string) ([]string, bool) {
+	quoted := strings.Split(pattern, wildcard)
+	for i, part := range quoted {
+		quoted[i] = regexp.QuoteMeta(part)
+	}
+	regex := regexp.MustCompile("^" + strings.Join(quoted, "(.*)") + "$")
+
+	matches := regex.FindStringSubmatch(name)
+	if matches == nil {
+		return nil, false
+	}
+	return matches[1:], true
+}
+
+// replaceWildcard replaces the wildcard identifier in the release type, empty wildcards will be removed
+func replaceWildcard(releaseType, value string) string {
+	if value == "" {
+		return strings.Trim(strings.ReplaceAll(releaseType, "."+wildcard, ""), ".")
+	}
+	return strings.ReplaceAll(releaseType, wildcard, value)
+}
+
+// sanitize converts a branch name part into a valid semver prerelease identifier
+func sanitize(value string) string {
+	return strings.Trim(invalidIdentifierChars.ReplaceAllString(value, "-"), "-")
+}
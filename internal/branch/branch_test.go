@@ -0,0 +1,111 @@
+package branch_test
+
+import (
+	"testing"
+
+	"github.com/Nightapes/go-semantic-release/internal/branch"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFind(t *testing.T) {
+
+	branches := map[string]string{
+		"master":       "release",
+		"beta":         "beta.*",
+		"beta-*":       "beta.*",
+		"beta-fix-*":   "beta",
+		"release/*":    "rc.*",
+		"alpha-*":      "alpha",
+		"*":            "none",
+		"feature/*/ui": "alpha.*",
+	}
+
+	testConfigs := []struct {
+		testCase    string
+		branch      string
+		found       bool
+		pattern     string
+		releaseType string
+		wildcard    string
+	}{
+		{
+			testCase:    "exact match",
+			branch:      "master",
+			found:       true,
+			pattern:     "master",
+			releaseType: "release",
+		},
+		{
+			testCase:    "exact match beats glob, wildcard removed",
+			branch:      "beta",
+			found:       true,
+			pattern:     "beta",
+			releaseType: "beta",
+		},
+		{
+			testCase:    "glob with wildcard identifier",
+			branch:      "beta-payments",
+			found:       true,
+			pattern:     "beta-*",
+			releaseType: "beta.payments",
+			wildcard:    "payments",
+		},
+		{
+			testCase:    "longest glob wins",
+			branch:      "beta-fix-login",
+			found:       true,
+			pattern:     "beta-fix-*",
+			releaseType: "beta",
+			wildcard:    "login",
+		},
+		{
+			testCase:    "glob with slash and invalid chars",
+			branch:      "release/2.3_hotfix",
+			found:       true,
+			pattern:     "release/*",
+			releaseType: "rc.2-3-hotfix",
+			wildcard:    "2-3-hotfix",
+		},
+		{
+			testCase:    "glob without wildcard identifier",
+			branch:      "alpha-test",
+			found:       true,
+			pattern:     "alpha-*",
+			releaseType: "alpha",
+			wildcard:    "test",
+		},
+		{
+			testCase:    "wildcard in the middle",
+			branch:      "feature/login/ui",
+			found:       true,
+			pattern:     "feature/*/ui",
+			releaseType: "alpha.login",
+			wildcard:    "login",
+		},
+		{
+			testCase:    "catch all",
+			branch:      "feature/login",
+			found:       true,
+			pattern:     "*",
+			releaseType: "none",
+			wildcard:    "feature-login",
+		},
+	}
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			match, found := branch.Find(branches, test.branch)
+			assert.Equal(t, test.found, found)
+			if test.found {
+				assert.Equal(t, test.pattern, match.Pattern)
+				assert.Equal(t, test.releaseType, match.ReleaseType)
+				assert.Equal(t, test.wildcard, match.Wildcard)
+			}
+		})
+	}
+}
+
+func TestFindNoMatch(t *testing.T) {
+	_, found := branch.Find(map[string]string{"master": "release", "beta-*": "beta"}, "beta")
+	assert.False(t, found)
+}
@@ -16,11 +16,12 @@ import (
 func Write(repository string, releaseVersion shared.ReleaseVersion) error {
 	completePath := path.Join(path.Dir(repository), ".version")
 
-	if releaseVersion.Last.Version != nil {
+	// calendar versions are already formatted, like 2024.05.0
+	if releaseVersion.Last.Version != nil && releaseVersion.Last.VersionString == "" {
 		releaseVersion.Last.VersionString = releaseVersion.Last.Version.String()
 	}
 
-	if releaseVersion.Next.Version != nil {
+	if releaseVersion.Next.Version != nil && releaseVersion.Next.VersionString == "" {
 		releaseVersion.Next.VersionString = releaseVersion.Next.Version.String()
 	}
 
@@ -30,7 +31,7 @@ func Write(repository string, releaseVersion shared.ReleaseVersion) error {
 		return err
 	}
 
-	log.Infof("Save %s with hash %s to cache %s", releaseVersion.Next.Version.String(), releaseVersion.Next.Commit, completePath)
+	log.Infof("Save %s with hash %s to cache %s", releaseVersion.Next.String(), releaseVersion.Next.Commit, completePath)
 	return ioutil.WriteFile(completePath, data, 0644)
 }
 
@@ -60,6 +61,6 @@ func Read(repository string) (*shared.ReleaseVersion, error) {
 		return nil, err
 	}
 
-	log.Infof("Found cache, will return cached version %s", parsedContent.Next.Version)
+	log.Infof("Found cache, will return cached version %s", parsedContent.Next.String())
 	return &parsedContent, nil
 }
@@ -87,6 +87,36 @@ func TestWriteAndReadCache(t *testing.T) {
 
 }
 
+func TestWriteAndReadCacheCalVer(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "prefix")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	content := shared.ReleaseVersion{
+		Last: shared.ReleaseVersionEntry{
+			Commit:        "12345",
+			Version:       createVersion("2024.04.3"),
+			VersionString: "2024.04.3",
+		},
+		Next: shared.ReleaseVersionEntry{
+			Commit:        "12346",
+			Version:       createVersion("2024.05.0"),
+			VersionString: "2024.05.0",
+		},
+		Branch: "master",
+	}
+
+	writeError := cache.Write(dir, content)
+	assert.NoErrorf(t, writeError, "Should write file")
+	result, readError := cache.Read(dir)
+	assert.NoErrorf(t, readError, "Should read file")
+
+	assert.Equal(t, "2024.04.3", result.Last.String())
+	assert.Equal(t, "2024.05.0", result.Next.String())
+	assert.Equal(t, int64(5), result.Next.Version.Minor())
+}
+
 func TestWriteNotFound(t *testing.T) {
 
 	err := cache.Write("notfound/dir", shared.ReleaseVersion{
@@ -1,54 +1,130 @@
 package calculator
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
 
 	log "github.com/sirupsen/logrus"
 )
 
 // Calculator struct
-type Calculator struct{}
-
-// New Calculator struct
-func New() *Calculator {
-	return &Calculator{}
+type Calculator struct {
+	initialDevelopment bool
+	calver             *calVerFormat
+	now                time.Time
+	versions           []*semver.Version
 }
 
-//IncPrerelease increase prerelease by one
-func (c *Calculator) IncPrerelease(preReleaseType string, version semver.Version) (semver.Version, error) {
-	defaultPrerelease := preReleaseType + ".0"
-	if !c.hasPrerelease(version, preReleaseType) {
-		return version.SetPrerelease(defaultPrerelease)
+// New Calculator struct, now is used as release date for calendar versions
+func New(c *config.ReleaseConfig, now time.Time) (*Calculator, error) {
+	calculator := &Calculator{
+		initialDevelopment: c.InitialDevelopment,
+		now:                now,
 	}
 
-	parts := strings.Split(version.Prerelease(), ".")
-	if len(parts) == 2 {
-		i, err := strconv.Atoi(parts[1])
+	switch c.Versioning.Scheme {
+	case "", SEMVER:
+	case CALVER:
+		format, err := parseCalVerFormat(c.Versioning.Format)
 		if err != nil {
-			log.Warnf("Could not parse release tag %s, use version %s", version.Prerelease(), version.String())
-			return version.SetPrerelease(defaultPrerelease)
+			return nil, err
 		}
-		return version.SetPrerelease(preReleaseType + "." + strconv.Itoa(i+1))
+		calculator.calver = format
+	default:
+		return nil, fmt.Errorf("versioning scheme %s is not supported, use %s or %s", c.Versioning.Scheme, SEMVER, CALVER)
+	}
+	return calculator, nil
+}
+
+// FirstVersion used if there is no release yet, 0.1.0 for initial development, 1.0.0 otherwise
+func (c *Calculator) FirstVersion() *semver.Version {
+	if c.calver != nil {
+		version := c.calver.version(c.now, 0)
+		return &version
+	}
+	if c.initialDevelopment {
+		return semver.MustParse("0.1.0")
+	}
+	return semver.MustParse("1.0.0")
+}
 
+// FormatVersion as string, calendar versions keep zero padded segments like 2024.05.0
+func (c *Calculator) FormatVersion(version *semver.Version) string {
+	if c.calver != nil {
+		return c.calver.format(version)
 	}
-	log.Warnf("Could not parse release tag %s, use version %s", version.Prerelease(), version.String())
-	return version.SetPrerelease(defaultPrerelease)
+	return version.String()
+}
+
+// IsInitialDevelopment returns true if version is a 0.y.z version and initial development is enabled
+func (c *Calculator) IsInitialDevelopment(version *semver.Version) bool {
+	return c.calver == nil && c.initialDevelopment && version.Major() == 0
+}
+
+// SetVersions of all existing tags, used to find the next free prerelease number
+func (c *Calculator) SetVersions(versions []*semver.Version) {
+	c.versions = versions
+}
+
+//IncPrerelease increase prerelease by one, preReleaseType can contain additional identifiers like beta.payments.
+//The number is higher than the numbers of all existing tags of the same version and preReleaseType
+func (c *Calculator) IncPrerelease(preReleaseType string, version semver.Version) (semver.Version, error) {
+	next := 0
+	if counter, ok := prereleaseCounter(version, preReleaseType); ok {
+		next = counter + 1
+	}
+
+	for _, existing := range c.versions {
+		if existing.Major() != version.Major() || existing.Minor() != version.Minor() || existing.Patch() != version.Patch() {
+			continue
+		}
+		if counter, ok := prereleaseCounter(*existing, preReleaseType); ok && counter >= next {
+			log.Debugf("Found existing prerelease %s, skip number %d", existing.String(), next)
+			next = counter + 1
+		}
+	}
+
+	return version.SetPrerelease(preReleaseType + "." + strconv.Itoa(next))
 }
 
 func (c *Calculator) hasPrerelease(version semver.Version, preReleaseType string) bool {
-	if version.Prerelease() == "" || !strings.HasPrefix(version.Prerelease(), preReleaseType) {
-		return false
+	_, ok := prereleaseCounter(version, preReleaseType)
+	return ok
+}
+
+// prereleaseCounter of versions like beta.2, beta2, beta-2 or beta, the counter of beta without number is -1
+func prereleaseCounter(version semver.Version, preReleaseType string) (int, bool) {
+	prerelease := version.Prerelease()
+	if prerelease == preReleaseType {
+		return -1, true
+	}
+	if !strings.HasPrefix(prerelease, preReleaseType) {
+		return 0, false
 	}
-	return true
+
+	counter := strings.TrimPrefix(prerelease, preReleaseType)
+	counter = strings.TrimPrefix(strings.TrimPrefix(counter, "."), "-")
+	i, err := strconv.Atoi(counter)
+	if err != nil || i < 0 || strings.HasPrefix(counter, "+") {
+		return 0, false
+	}
+	return i, true
 }
 
-//CalculateNewVersion from given commits and lastversion
-func (c *Calculator) CalculateNewVersion(commits map[shared.Release][]shared.AnalyzedCommit, lastVersion *semver.Version, releaseType string, firstRelease bool) semver.Version {
-	switch releaseType {
+//CalculateNewVersion from given commits and lastversion, branch is used for the version range of maintenance branches.
+// For calendar versions the commits only decide if there is a new release
+func (c *Calculator) CalculateNewVersion(commits map[shared.Release][]shared.AnalyzedCommit, lastVersion *semver.Version, releaseType, branch string, firstRelease bool) (semver.Version, error) {
+	if c.calver != nil {
+		return c.calculateCalVersion(commits, lastVersion, releaseType, firstRelease)
+	}
+
+	switch strings.SplitN(releaseType, ".", 2)[0] {
 	case "beta", "alpha", "rc":
 		var version = *lastVersion
 		if !c.hasPrerelease(*lastVersion, releaseType) {
@@ -56,26 +132,36 @@ func (c *Calculator) CalculateNewVersion(commits map[shared.Release][]shared.Ana
 		}
 
 		if len(commits["major"]) > 0 || len(commits["minor"]) > 0 || len(commits["patch"]) > 0 {
-			version, _ := c.IncPrerelease(releaseType, version)
-			return version
+			return c.IncPrerelease(releaseType, version)
 		}
 	case "release":
 		if !firstRelease {
 			if lastVersion.Prerelease() != "" {
-				newVersion, _ := lastVersion.SetPrerelease("")
-				return newVersion
+				return lastVersion.SetPrerelease("")
 			}
 			version, done := c.inc(commits, lastVersion)
 			if done {
-				return version
+				return version, nil
 			}
 		}
+	case MAINTENANCE:
+		return c.calculateMaintenanceVersion(commits, lastVersion, branch)
 	}
 
-	return *lastVersion
+	return *lastVersion, nil
 }
 
 func (c *Calculator) inc(commits map[shared.Release][]shared.AnalyzedCommit, lastVersion *semver.Version) (semver.Version, bool) {
+	if c.IsInitialDevelopment(lastVersion) {
+		// breaking changes only increase the minor version, till 1.0.0 is set explicitly
+		if len(commits["major"]) > 0 {
+			return lastVersion.IncMinor(), true
+		} else if len(commits["minor"]) > 0 || len(commits["patch"]) > 0 {
+			return lastVersion.IncPatch(), true
+		}
+		return semver.Version{}, false
+	}
+
 	if len(commits["major"]) > 0 {
 		return lastVersion.IncMajor(), true
 	} else if len(commits["minor"]) > 0 {
@@ -2,10 +2,12 @@ package calculator_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/Masterminds/semver"
 	"github.com/Nightapes/go-semantic-release/internal/calculator"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -41,6 +43,18 @@ func TestCalculator_IncPrerelease(t *testing.T) {
 			lastVersion:    createVersion("1.0.0-alpha.0"),
 			nextVersion:    "1.0.0-beta.0",
 		},
+		{
+			testCase:       "version with preRelease and identifier",
+			preReleaseType: "beta.payments",
+			lastVersion:    createVersion("1.3.0-beta.payments.1"),
+			nextVersion:    "1.3.0-beta.payments.2",
+		},
+		{
+			testCase:       "version with other identifier",
+			preReleaseType: "beta.payments",
+			lastVersion:    createVersion("1.3.0-beta.1"),
+			nextVersion:    "1.3.0-beta.payments.0",
+		},
 		{
 			testCase:       "version with preRelease but broken",
 			preReleaseType: "alpha",
@@ -53,9 +67,21 @@ func TestCalculator_IncPrerelease(t *testing.T) {
 			lastVersion:    createVersion("1.0.0-alphabr0ken"),
 			nextVersion:    "1.0.0-alpha.0",
 		},
+		{
+			testCase:       "version with preRelease without number",
+			preReleaseType: "beta",
+			lastVersion:    createVersion("1.0.0-beta"),
+			nextVersion:    "1.0.0-beta.0",
+		},
+		{
+			testCase:       "version with preRelease without dot",
+			preReleaseType: "rc",
+			lastVersion:    createVersion("1.0.0-rc3"),
+			nextVersion:    "1.0.0-rc.4",
+		},
 	}
 
-	c := calculator.New()
+	c, _ := calculator.New(&config.ReleaseConfig{}, time.Now())
 
 	for _, test := range testConfigs {
 		t.Run(test.testCase, func(t *testing.T) {
@@ -67,6 +93,64 @@ func TestCalculator_IncPrerelease(t *testing.T) {
 
 }
 
+func TestCalculator_IncPrerelease_ExistingTags(t *testing.T) {
+
+	testConfigs := []struct {
+		testCase       string
+		preReleaseType string
+		lastVersion    *semver.Version
+		nextVersion    string
+	}{
+		{
+			testCase:       "tag of other branch",
+			preReleaseType: "beta",
+			lastVersion:    createVersion("1.3.0-beta.0"),
+			nextVersion:    "1.3.0-beta.3",
+		},
+		{
+			testCase:       "last version is a release",
+			preReleaseType: "beta",
+			lastVersion:    createVersion("1.3.0"),
+			nextVersion:    "1.3.0-beta.3",
+		},
+		{
+			testCase:       "other channel",
+			preReleaseType: "rc",
+			lastVersion:    createVersion("1.3.0"),
+			nextVersion:    "1.3.0-rc.1",
+		},
+		{
+			testCase:       "other version",
+			preReleaseType: "beta",
+			lastVersion:    createVersion("1.4.0"),
+			nextVersion:    "1.4.0-beta.0",
+		},
+		{
+			testCase:       "channel with identifier",
+			preReleaseType: "beta.payments",
+			lastVersion:    createVersion("1.3.0"),
+			nextVersion:    "1.3.0-beta.payments.0",
+		},
+	}
+
+	c, _ := calculator.New(&config.ReleaseConfig{}, time.Now())
+	c.SetVersions([]*semver.Version{
+		createVersion("1.3.0-beta.2"),
+		createVersion("1.3.0-beta.1"),
+		createVersion("1.3.0-rc0"),
+		createVersion("1.2.0-beta.7"),
+		createVersion("1.2.0"),
+	})
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			next, err := c.IncPrerelease(test.preReleaseType, *test.lastVersion)
+			assert.NoError(t, err)
+			assert.Equal(t, test.nextVersion, next.String())
+		})
+	}
+}
+
 func TestCalculator_CalculateNewVersion(t *testing.T) {
 
 	testConfigs := []struct {
@@ -107,6 +191,21 @@ func TestCalculator_CalculateNewVersion(t *testing.T) {
 			},
 			isFirst: false,
 		},
+		{
+			testCase:    "version with preRelease beta and identifier",
+			releaseType: "beta.payments",
+			lastVersion: createVersion("1.2.0"),
+			nextVersion: "1.3.0-beta.payments.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"major": {},
+				"minor": {
+					{},
+				},
+				"patch": {},
+				"none":  {},
+			},
+			isFirst: false,
+		},
 		{
 			testCase:    "version without commits",
 			releaseType: "alpha",
@@ -200,13 +299,185 @@ func TestCalculator_CalculateNewVersion(t *testing.T) {
 		},
 	}
 
-	c := calculator.New()
+	c, _ := calculator.New(&config.ReleaseConfig{}, time.Now())
 
 	for _, test := range testConfigs {
 		t.Run(test.testCase, func(t *testing.T) {
-			next := c.CalculateNewVersion(test.analyzedCommits, test.lastVersion, test.releaseType, test.isFirst)
+			next, err := c.CalculateNewVersion(test.analyzedCommits, test.lastVersion, test.releaseType, "master", test.isFirst)
+			assert.NoError(t, err)
 			assert.Equalf(t, test.nextVersion, next.String(), "Should have version %s for testcase %s", test.nextVersion, test.testCase)
 		})
 	}
 
 }
+
+func TestCalculator_CalculateNewVersion_Maintenance(t *testing.T) {
+
+	testConfigs := []struct {
+		testCase        string
+		branch          string
+		lastVersion     *semver.Version
+		nextVersion     string
+		hasError        bool
+		analyzedCommits map[shared.Release][]shared.AnalyzedCommit
+	}{
+		{
+			testCase:    "minor on major branch",
+			branch:      "1.x",
+			lastVersion: createVersion("1.4.2"),
+			nextVersion: "1.5.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {{}},
+			},
+		},
+		{
+			testCase:    "breaking change on major branch",
+			branch:      "1.x",
+			lastVersion: createVersion("1.4.2"),
+			nextVersion: "1.4.2",
+			hasError:    true,
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"major": {{}},
+			},
+		},
+		{
+			testCase:    "patch on minor branch",
+			branch:      "release-2.3",
+			lastVersion: createVersion("2.3.4"),
+			nextVersion: "2.3.5",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"patch": {{}},
+			},
+		},
+		{
+			testCase:    "feat on minor branch",
+			branch:      "2.3.x",
+			lastVersion: createVersion("2.3.4"),
+			nextVersion: "2.3.4",
+			hasError:    true,
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {{}},
+				"patch": {{}},
+			},
+		},
+		{
+			testCase:    "no release commits",
+			branch:      "2.3.x",
+			lastVersion: createVersion("2.3.4"),
+			nextVersion: "2.3.4",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"none": {{}},
+			},
+		},
+		{
+			testCase:    "last version outside of range",
+			branch:      "2.3.x",
+			lastVersion: createVersion("2.4.0"),
+			nextVersion: "2.4.0",
+			hasError:    true,
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"patch": {{}},
+			},
+		},
+		{
+			testCase:    "branch without range",
+			branch:      "maintenance",
+			lastVersion: createVersion("2.3.4"),
+			nextVersion: "2.3.4",
+			hasError:    true,
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"patch": {{}},
+			},
+		},
+	}
+
+	c, _ := calculator.New(&config.ReleaseConfig{}, time.Now())
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			next, err := c.CalculateNewVersion(test.analyzedCommits, test.lastVersion, "maintenance", test.branch, false)
+			assert.Equalf(t, test.hasError, err != nil, "Testcase %s should have error: %t -> %s", test.testCase, test.hasError, err)
+			assert.Equal(t, test.nextVersion, next.String())
+		})
+	}
+}
+
+func TestParseVersionRange(t *testing.T) {
+	for branch, expected := range map[string]string{
+		"1.x":           "1.x",
+		"1.x.x":         "1.x",
+		"v2.x":          "2.x",
+		"2.3.x":         "2.3.x",
+		"release-2.3":   "2.3.x",
+		"release/10.12": "10.12.x",
+	} {
+		versionRange, err := calculator.ParseVersionRange(branch)
+		assert.NoError(t, err)
+		assert.Equalf(t, expected, versionRange.String(), "branch %s", branch)
+	}
+
+	for _, branch := range []string{"master", "release-2.3.1", "1.2.3.x"} {
+		_, err := calculator.ParseVersionRange(branch)
+		assert.Errorf(t, err, "branch %s", branch)
+	}
+}
+
+func TestCalculator_CalculateNewVersion_InitialDevelopment(t *testing.T) {
+
+	testConfigs := []struct {
+		testCase        string
+		releaseType     string
+		lastVersion     *semver.Version
+		nextVersion     string
+		analyzedCommits map[shared.Release][]shared.AnalyzedCommit
+	}{
+		{
+			testCase:    "breaking change increases minor",
+			releaseType: "release",
+			lastVersion: createVersion("0.3.2"),
+			nextVersion: "0.4.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"major": {{}},
+				"minor": {{}},
+			},
+		},
+		{
+			testCase:    "feature increases patch",
+			releaseType: "release",
+			lastVersion: createVersion("0.3.2"),
+			nextVersion: "0.3.3",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {{}},
+			},
+		},
+		{
+			testCase:    "breaking change on prerelease",
+			releaseType: "beta",
+			lastVersion: createVersion("0.3.2"),
+			nextVersion: "0.4.0-beta.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"major": {{}},
+			},
+		},
+		{
+			testCase:    "after 1.0.0",
+			releaseType: "release",
+			lastVersion: createVersion("1.0.0"),
+			nextVersion: "2.0.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"major": {{}},
+			},
+		},
+	}
+
+	c, _ := calculator.New(&config.ReleaseConfig{InitialDevelopment: true}, time.Now())
+	assert.Equal(t, "0.1.0", c.FirstVersion().String())
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			next, err := c.CalculateNewVersion(test.analyzedCommits, test.lastVersion, test.releaseType, "master", false)
+			assert.NoError(t, err)
+			assert.Equal(t, test.nextVersion, next.String())
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package calculator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// SEMVER versioning scheme, default
+const SEMVER = "semver"
+
+// CALVER versioning scheme, versions are calculated from the release date
+const CALVER = "calver"
+
+// calVerCounters are the allowed names for the last segment of a calver format
+var calVerCounters = map[string]bool{
+	"MICRO": true,
+	"PATCH": true,
+}
+
+// calVerTokens returns the value of a date segment, see https://calver.org
+var calVerTokens = map[string]func(year int, date time.Time) int{
+	"YYYY": func(year int, date time.Time) int { return year },
+	"YY":   func(year int, date time.Time) int { return year - 2000 },
+	"0Y":   func(year int, date time.Time) int { return year - 2000 },
+	"MM":   func(year int, date time.Time) int { return int(date.Month()) },
+	"0M":   func(year int, date time.Time) int { return int(date.Month()) },
+	"WW":   func(year int, date time.Time) int { _, week := date.ISOWeek(); return week },
+	"0W":   func(year int, date time.Time) int { _, week := date.ISOWeek(); return week },
+	"DD":   func(year int, date time.Time) int { return date.Day() },
+	"0D":   func(year int, date time.Time) int { return date.Day() },
+}
+
+// calVerFormat like YYYY.0M.MICRO, two date segments followed by a counter
+type calVerFormat struct {
+	segments [2]string
+	counter  string
+}
+
+func parseCalVerFormat(format string) (*calVerFormat, error) {
+	parts := strings.Split(format, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("calver format %s needs three segments like YYYY.0M.MICRO", format)
+	}
+
+	for _, part := range parts[:2] {
+		if _, ok := calVerTokens[part]; !ok {
+			return nil, fmt.Errorf("unknown date segment %s in calver format %s", part, format)
+		}
+	}
+
+	if !calVerCounters[parts[2]] {
+		return nil, fmt.Errorf("calver format %s needs to end with MICRO or PATCH", format)
+	}
+
+	return &calVerFormat{
+		segments: [2]string{parts[0], parts[1]},
+		counter:  parts[2],
+	}, nil
+}
+
+// date returns the values of both date segments, the ISO year is used together with weeks
+func (f *calVerFormat) date(now time.Time) (int64, int64) {
+	year := now.Year()
+	if f.hasWeek() {
+		year, _ = now.ISOWeek()
+	}
+	return int64(calVerTokens[f.segments[0]](year, now)), int64(calVerTokens[f.segments[1]](year, now))
+}
+
+func (f *calVerFormat) hasWeek() bool {
+	for _, segment := range f.segments {
+		if strings.HasSuffix(segment, "W") {
+			return true
+		}
+	}
+	return false
+}
+
+// version with counter for the given date
+func (f *calVerFormat) version(now time.Time, counter int64) semver.Version {
+	major, minor := f.date(now)
+	version, _ := semver.NewVersion(fmt.Sprintf("%d.%d.%d", major, minor, counter))
+	return *version
+}
+
+// format keeps zero padded segments like 2024.05.0
+func (f *calVerFormat) format(version *semver.Version) string {
+	values := [2]int64{version.Major(), version.Minor()}
+	formatted := make([]string, 0, 3)
+	for i, segment := range f.segments {
+		if strings.HasPrefix(segment, "0") {
+			formatted = append(formatted, fmt.Sprintf("%02d", values[i]))
+		} else {
+			formatted = append(formatted, fmt.Sprintf("%d", values[i]))
+		}
+	}
+	formatted = append(formatted, fmt.Sprintf("%d", version.Patch()))
+
+	result := strings.Join(formatted, ".")
+	if version.Prerelease() != "" {
+		result += "-" + version.Prerelease()
+	}
+	if version.Metadata() != "" {
+		result += "+" + version.Metadata()
+	}
+	return result
+}
+
+// calculateCalVersion from the release date, commits only decide if there is a new release
+func (c *Calculator) calculateCalVersion(commits map[shared.Release][]shared.AnalyzedCommit, lastVersion *semver.Version, releaseType string, firstRelease bool) (semver.Version, error) {
+	major, minor := c.calver.date(c.now)
+	samePeriod := lastVersion.Major() == major && lastVersion.Minor() == minor
+
+	// next release of the current period, an open prerelease of this period is released with its version
+	next := c.calver.version(c.now, 0)
+	if firstRelease {
+		next = *lastVersion
+	} else if samePeriod && lastVersion.Prerelease() != "" {
+		next, _ = lastVersion.SetPrerelease("")
+	} else if samePeriod {
+		next = c.calver.version(c.now, lastVersion.Patch()+1)
+	}
+
+	hasChanges := len(commits["major"]) > 0 || len(commits["minor"]) > 0 || len(commits["patch"]) > 0
+
+	switch strings.SplitN(releaseType, ".", 2)[0] {
+	case "beta", "alpha", "rc":
+		if !hasChanges && !firstRelease {
+			return *lastVersion, nil
+		}
+		if samePeriod && c.hasPrerelease(*lastVersion, releaseType) {
+			return c.IncPrerelease(releaseType, *lastVersion)
+		}
+		return c.IncPrerelease(releaseType, next)
+	case "release":
+		if firstRelease || lastVersion.Prerelease() != "" || hasChanges {
+			return next, nil
+		}
+	case MAINTENANCE:
+		return *lastVersion, fmt.Errorf("maintenance branches are not supported with calver")
+	}
+
+	return *lastVersion, nil
+}
@@ -0,0 +1,135 @@
+package calculator_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Nightapes/go-semantic-release/internal/calculator"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculator_CalculateNewVersion_CalVer(t *testing.T) {
+
+	may := time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	testConfigs := []struct {
+		testCase        string
+		format          string
+		releaseType     string
+		lastVersion     string
+		isFirst         bool
+		nextVersion     string
+		analyzedCommits map[shared.Release][]shared.AnalyzedCommit
+	}{
+		{
+			testCase:    "new month",
+			format:      "YYYY.0M.MICRO",
+			releaseType: "release",
+			lastVersion: "2024.4.3",
+			nextVersion: "2024.05.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"patch": {{}},
+			},
+		},
+		{
+			testCase:    "same month, breaking change",
+			format:      "YYYY.MM.MICRO",
+			releaseType: "release",
+			lastVersion: "2024.5.0",
+			nextVersion: "2024.5.1",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"major": {{}},
+			},
+		},
+		{
+			testCase:    "no release commits",
+			format:      "YYYY.MM.MICRO",
+			releaseType: "release",
+			lastVersion: "2024.4.3",
+			nextVersion: "2024.4.3",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"none": {{}},
+			},
+		},
+		{
+			testCase:    "first release",
+			format:      "YY.0W.PATCH",
+			releaseType: "release",
+			lastVersion: "24.20.0",
+			isFirst:     true,
+			nextVersion: "24.20.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"none": {{}},
+			},
+		},
+		{
+			testCase:    "week",
+			format:      "YY.0W.PATCH",
+			releaseType: "release",
+			lastVersion: "24.20.0",
+			nextVersion: "24.20.1",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {{}},
+			},
+		},
+		{
+			testCase:    "prerelease in new month",
+			format:      "YYYY.0M.MICRO",
+			releaseType: "rc",
+			lastVersion: "2024.4.3",
+			nextVersion: "2024.05.0-rc.0",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"minor": {{}},
+			},
+		},
+		{
+			testCase:    "increase prerelease",
+			format:      "YYYY.0M.MICRO",
+			releaseType: "rc",
+			lastVersion: "2024.5.1-rc.0",
+			nextVersion: "2024.05.1-rc.1",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"patch": {{}},
+			},
+		},
+		{
+			testCase:    "release prerelease",
+			format:      "YYYY.0M.MICRO",
+			releaseType: "release",
+			lastVersion: "2024.5.1-rc.1",
+			nextVersion: "2024.05.1",
+			analyzedCommits: map[shared.Release][]shared.AnalyzedCommit{
+				"none": {{}},
+			},
+		},
+	}
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			c, err := calculator.New(&config.ReleaseConfig{Versioning: config.VersioningConfig{Scheme: "calver", Format: test.format}}, may)
+			assert.NoError(t, err)
+
+			next, err := c.CalculateNewVersion(test.analyzedCommits, createVersion(test.lastVersion), test.releaseType, "master", test.isFirst)
+			assert.NoError(t, err)
+			assert.Equal(t, test.nextVersion, c.FormatVersion(&next))
+		})
+	}
+}
+
+func TestCalculator_New_CalVer(t *testing.T) {
+	may := time.Date(2024, 5, 14, 12, 0, 0, 0, time.UTC)
+
+	c, err := calculator.New(&config.ReleaseConfig{Versioning: config.VersioningConfig{Scheme: "calver", Format: "0Y.0M.MICRO"}}, may)
+	assert.NoError(t, err)
+	assert.Equal(t, "24.05.0", c.FormatVersion(c.FirstVersion()))
+
+	for _, format := range []string{"", "YYYY.MM", "YYYY.MM.DD", "YYYY.XX.MICRO", "YYYY.MM.DD.MICRO"} {
+		_, err := calculator.New(&config.ReleaseConfig{Versioning: config.VersioningConfig{Scheme: "calver", Format: format}}, may)
+		assert.Errorf(t, err, "format %s should be invalid", format)
+	}
+
+	_, err = calculator.New(&config.ReleaseConfig{Versioning: config.VersioningConfig{Scheme: "romver"}}, may)
+	assert.Error(t, err)
+}
@@ -0,0 +1,80 @@
+package calculator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/Masterminds/semver"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// MAINTENANCE release type for branches of older versions, like 1.x or release-2.3
+const MAINTENANCE = "maintenance"
+
+var versionRangeRegex = regexp.MustCompile(`(?:^|[^0-9.])(?P<major>[0-9]+)\.(?P<minor>[0-9]+|x)(?:\.x)?$`)
+
+// VersionRange of a maintenance branch
+type VersionRange struct {
+	Major int64
+	// Minor is nil if all minor versions are allowed
+	Minor *int64
+}
+
+// ParseVersionRange from a branch name like 1.x, 1.x.x, 2.3.x or release-2.3
+func ParseVersionRange(branch string) (*VersionRange, error) {
+	matches := versionRangeRegex.FindStringSubmatch(branch)
+	if matches == nil {
+		return nil, fmt.Errorf("could not find a version range in maintenance branch %s, use a name like 1.x or release-2.3", branch)
+	}
+
+	versionRange := &VersionRange{}
+	versionRange.Major, _ = strconv.ParseInt(matches[versionRangeRegex.SubexpIndex("major")], 10, 64)
+
+	if minor := matches[versionRangeRegex.SubexpIndex("minor")]; minor != "x" {
+		parsedMinor, _ := strconv.ParseInt(minor, 10, 64)
+		versionRange.Minor = &parsedMinor
+	}
+	return versionRange, nil
+}
+
+// Contains checks if the version is inside of the range
+func (r VersionRange) Contains(version semver.Version) bool {
+	if version.Major() != r.Major {
+		return false
+	}
+	return r.Minor == nil || version.Minor() == *r.Minor
+}
+
+func (r VersionRange) String() string {
+	if r.Minor == nil {
+		return fmt.Sprintf("%d.x", r.Major)
+	}
+	return fmt.Sprintf("%d.%d.x", r.Major, *r.Minor)
+}
+
+// calculateMaintenanceVersion refuses versions outside of the range of the branch
+func (c *Calculator) calculateMaintenanceVersion(commits map[shared.Release][]shared.AnalyzedCommit, lastVersion *semver.Version, branch string) (semver.Version, error) {
+	versionRange, err := ParseVersionRange(branch)
+	if err != nil {
+		return *lastVersion, err
+	}
+
+	if !versionRange.Contains(*lastVersion) {
+		return *lastVersion, fmt.Errorf("last version %s is outside of the range %s of maintenance branch %s", lastVersion.String(), versionRange.String(), branch)
+	}
+
+	if lastVersion.Prerelease() != "" {
+		return lastVersion.SetPrerelease("")
+	}
+
+	version, done := c.inc(commits, lastVersion)
+	if !done {
+		return *lastVersion, nil
+	}
+
+	if !versionRange.Contains(version) {
+		return *lastVersion, fmt.Errorf("commits on maintenance branch %s would release %s, which is outside of the range %s", branch, version.String(), versionRange.String())
+	}
+	return version, nil
+}
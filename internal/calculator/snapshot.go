@@ -0,0 +1,34 @@
+package calculator
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+)
+
+// SNAPSHOT release type for builds of feature branches, snapshot versions are never released
+const SNAPSHOT = "snapshot"
+
+const snapshotPrerelease = "dev"
+
+// Snapshot version like 1.4.0-dev.23+g3d37f26 from the next version, the commits since the last version and the current commit
+func (c *Calculator) Snapshot(nextVersion, lastVersion semver.Version, commitCount int, hash string) (semver.Version, error) {
+	version := nextVersion
+	if !version.GreaterThan(&lastVersion) {
+		// no release commits, the snapshot still needs to be higher than the last version
+		version = lastVersion.IncPatch()
+	}
+
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+
+	version, err := version.SetPrerelease(fmt.Sprintf("%s.%d", snapshotPrerelease, commitCount))
+	if err != nil {
+		return version, err
+	}
+	if hash == "" {
+		return version, nil
+	}
+	return version.SetMetadata("g" + hash)
+}
@@ -0,0 +1,56 @@
+package calculator_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Nightapes/go-semantic-release/internal/calculator"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculator_Snapshot(t *testing.T) {
+
+	testConfigs := []struct {
+		testCase    string
+		nextVersion string
+		lastVersion string
+		commitCount int
+		hash        string
+		snapshot    string
+	}{
+		{
+			testCase:    "next version",
+			nextVersion: "1.4.0",
+			lastVersion: "1.3.2",
+			commitCount: 23,
+			hash:        "3d37f26a1b2c3d4e5f60718293a4b5c6d7e8f901",
+			snapshot:    "1.4.0-dev.23+g3d37f26",
+		},
+		{
+			testCase:    "no release commits",
+			nextVersion: "1.3.2",
+			lastVersion: "1.3.2",
+			commitCount: 2,
+			hash:        "3d37f26",
+			snapshot:    "1.3.3-dev.2+g3d37f26",
+		},
+		{
+			testCase:    "without hash",
+			nextVersion: "1.4.0",
+			lastVersion: "1.3.2",
+			commitCount: 1,
+			snapshot:    "1.4.0-dev.1",
+		},
+	}
+
+	c, _ := calculator.New(&config.ReleaseConfig{}, time.Now())
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			snapshot, err := c.Snapshot(*createVersion(test.nextVersion), *createVersion(test.lastVersion), test.commitCount, test.hash)
+			assert.NoError(t, err)
+			assert.Equal(t, test.snapshot, snapshot.String())
+		})
+	}
+}
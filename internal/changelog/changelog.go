@@ -150,18 +150,21 @@ func (c *Changelog) GenerateChangelog(templateConfig shared.ChangelogTemplateCon
 	commitsPerScope := map[string][]shared.AnalyzedCommit{}
 	var commitsBreakingChange []shared.AnalyzedCommit
 	order := make([]string, 0)
+	inOrder := map[string]bool{}
 
 	for _, rule := range c.rules {
 		c.log.Tracef("Add %s to list", rule.TagString)
-		if rule.Changelog || c.config.Changelog.PrintAll {
+		// several rules can share the same section
+		if (rule.Changelog || c.config.Changelog.PrintAll) && !inOrder[rule.TagString] {
 			order = append(order, rule.TagString)
+			inOrder[rule.TagString] = true
 		}
 	}
 
 	authors := map[string]bool{}
 
-	for _, commits := range analyzedCommits {
-		for _, commit := range commits {
+	for _, release := range releaseOrder(analyzedCommits) {
+		for _, commit := range analyzedCommits[release] {
 			authors[commit.Commit.Author] = true
 			if commit.Print {
 				if commit.IsBreaking {
@@ -176,6 +179,11 @@ func (c *Changelog) GenerateChangelog(templateConfig shared.ChangelogTemplateCon
 		}
 	}
 
+	sortByDate(commitsBreakingChange)
+	for _, commits := range commitsPerScope {
+		sortByDate(commits)
+	}
+
 	commitsContent := commitsContent{
 		Commits:          commitsPerScope,
 		BreakingChanges:  commitsBreakingChange,
@@ -249,6 +257,28 @@ func (c *Changelog) GenerateChangelog(templateConfig shared.ChangelogTemplateCon
 	return &shared.GeneratedChangelog{Title: renderedTitle, Content: renderedContent}, err
 }
 
+// releaseOrder returns the releases of the analyzed commits, major to none first, unknown releases sorted by name
+func releaseOrder(analyzedCommits map[shared.Release][]shared.AnalyzedCommit) []shared.Release {
+	order := []shared.Release{"major", "minor", "patch", "none"}
+	others := make([]shared.Release, 0)
+	for release := range analyzedCommits {
+		switch release {
+		case "major", "minor", "patch", "none":
+		default:
+			others = append(others, release)
+		}
+	}
+	sort.Slice(others, func(i, j int) bool { return others[i] < others[j] })
+	return append(order, others...)
+}
+
+// sortByDate sorts commits newest first, commits with the same date keep their order
+func sortByDate(commits []shared.AnalyzedCommit) {
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Commit.CommitterDate.After(commits[j].Commit.CommitterDate)
+	})
+}
+
 func generateTemplate(text string, values interface{}, extraFuncMap template.FuncMap) (string, error) {
 
 	funcMap := template.FuncMap{
@@ -412,3 +412,46 @@ func TestChangelogExtensions(t *testing.T) {
 	}
 
 }
+
+func TestChangelogSharedSection(t *testing.T) {
+
+	templateConfig := shared.ChangelogTemplateConfig{
+		Version: "1.0.0",
+	}
+
+	cl := changelog.New(&config.ReleaseConfig{}, []analyzer.Rule{
+		{
+			Tag:       "bug",
+			TagString: "Bug fixes",
+			Release:   "patch",
+			Changelog: true,
+		},
+		{
+			Tag:       "ambulance",
+			TagString: "Bug fixes",
+			Release:   "patch",
+			Changelog: true,
+		},
+	}, time.Date(2019, 7, 19, 0, 0, 0, 0, time.UTC))
+
+	generatedChangelog, err := cl.GenerateChangelog(templateConfig, map[shared.Release][]shared.AnalyzedCommit{
+		"patch": {
+			{
+				Commit:    shared.Commit{Message: ":bug: first fix", Author: "me", Hash: "12345667"},
+				Tag:       "bug",
+				TagString: "Bug fixes",
+				Print:     true,
+				Subject:   "first fix",
+			},
+			{
+				Commit:    shared.Commit{Message: ":ambulance: second fix", Author: "me", Hash: "12345668"},
+				Tag:       "ambulance",
+				TagString: "Bug fixes",
+				Print:     true,
+				Subject:   "second fix",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "# v1.0.0 (2019-07-19)\n### Bug fixes\n* first fix\n* second fix\n", generatedChangelog.Content)
+}
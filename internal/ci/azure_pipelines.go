@@ -0,0 +1,44 @@
+package ci
+
+import (
+	"fmt"
+	"strings"
+)
+
+//AzurePipelines struct
+type AzurePipelines struct{}
+
+//Detect if on Azure Pipelines
+func (t AzurePipelines) detect(envs map[string]string) (*ProviderConfig, error) {
+
+	if _, exists := envs["TF_BUILD"]; !exists {
+		return nil, fmt.Errorf("not running on azure pipelines")
+	}
+
+	config := &ProviderConfig{
+		Service:  "azure",
+		Name:     "Azure Pipelines",
+		Commit:   envs["BUILD_SOURCEVERSION"],
+		BuildURL: envs["SYSTEM_COLLECTIONURI"] + envs["SYSTEM_TEAMPROJECT"] + "/_build/results?buildId=" + envs["BUILD_BUILDID"],
+		IsPR:     false,
+	}
+
+	ref := envs["BUILD_SOURCEBRANCH"]
+	switch {
+	case strings.HasPrefix(ref, "refs/heads/"):
+		config.Branch = strings.TrimPrefix(ref, "refs/heads/")
+	case strings.HasPrefix(ref, "refs/tags/"):
+		config.Tag = strings.TrimPrefix(ref, "refs/tags/")
+	default:
+		config.Branch = ref
+	}
+
+	// on pull requests BUILD_SOURCEBRANCH is the merge ref, like refs/pull/1/merge
+	if pr, exists := envs["SYSTEM_PULLREQUEST_PULLREQUESTID"]; exists && pr != "" {
+		config.IsPR = true
+		config.PR = pr
+		config.PRBranch = strings.TrimPrefix(envs["SYSTEM_PULLREQUEST_SOURCEBRANCH"], "refs/heads/")
+		config.Branch = strings.TrimPrefix(envs["SYSTEM_PULLREQUEST_TARGETBRANCH"], "refs/heads/")
+	}
+	return config, nil
+}
@@ -0,0 +1,35 @@
+package ci
+
+import (
+	"fmt"
+)
+
+//BitbucketPipelines struct
+type BitbucketPipelines struct{}
+
+//Detect if on Bitbucket Pipelines
+func (t BitbucketPipelines) detect(envs map[string]string) (*ProviderConfig, error) {
+
+	if _, exists := envs["BITBUCKET_BUILD_NUMBER"]; !exists {
+		return nil, fmt.Errorf("not running on bitbucket pipelines")
+	}
+
+	config := &ProviderConfig{
+		Service:  "bitbucket",
+		Name:     "Bitbucket Pipelines",
+		Commit:   envs["BITBUCKET_COMMIT"],
+		Tag:      envs["BITBUCKET_TAG"],
+		BuildURL: envs["BITBUCKET_GIT_HTTP_ORIGIN"] + "/addon/pipelines/home#!/results/" + envs["BITBUCKET_BUILD_NUMBER"],
+		Branch:   envs["BITBUCKET_BRANCH"],
+		IsPR:     false,
+	}
+
+	// on pull requests BITBUCKET_BRANCH is the source branch
+	if pr, exists := envs["BITBUCKET_PR_ID"]; exists && pr != "" {
+		config.IsPR = true
+		config.PR = pr
+		config.PRBranch = envs["BITBUCKET_BRANCH"]
+		config.Branch = envs["BITBUCKET_PR_DESTINATION_BRANCH"]
+	}
+	return config, nil
+}
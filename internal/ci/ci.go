@@ -45,6 +45,8 @@ func GetCIProvider(gitUtil *gitutil.GitUtil, configCheck bool, envs map[string]s
 		Travis{},
 		GithubActions{},
 		GitlabCI{},
+		BitbucketPipelines{},
+		AzurePipelines{},
 		Git{gitUtil: gitUtil}, // Git must be the last option to check
 	}
 
@@ -124,6 +124,59 @@ func TestCi(t *testing.T) {
 			result:   &ci.ProviderConfig{IsPR: false, PR: "", PRBranch: "", Branch: "master", Tag: "tag", Commit: "190bfd6aa60022afd0ef830342cfb07e33c45f37", BuildURL: "https://my.gitlab.com/pipelines/1", Service: "gitlab", Name: "GitLab CI/CD"},
 			hasError: false,
 		},
+		{
+			service: "Bitbucket Pipelines Push",
+			envs: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":    "7",
+				"BITBUCKET_COMMIT":          "190bfd6aa60022afd0ef830342cfb07e33c45f37",
+				"BITBUCKET_BRANCH":          "master",
+				"BITBUCKET_GIT_HTTP_ORIGIN": "https://bitbucket.org/owner/repo",
+			},
+			result:   &ci.ProviderConfig{IsPR: false, PR: "", PRBranch: "", Branch: "master", Tag: "", Commit: "190bfd6aa60022afd0ef830342cfb07e33c45f37", BuildURL: "https://bitbucket.org/owner/repo/addon/pipelines/home#!/results/7", Service: "bitbucket", Name: "Bitbucket Pipelines"},
+			hasError: false,
+		},
+		{
+			service: "Bitbucket Pipelines PR",
+			envs: map[string]string{
+				"BITBUCKET_BUILD_NUMBER":          "8",
+				"BITBUCKET_COMMIT":                "190bfd6aa60022afd0ef830342cfb07e33c45f37",
+				"BITBUCKET_BRANCH":                "feature",
+				"BITBUCKET_PR_ID":                 "12",
+				"BITBUCKET_PR_DESTINATION_BRANCH": "master",
+				"BITBUCKET_GIT_HTTP_ORIGIN":       "https://bitbucket.org/owner/repo",
+			},
+			result:   &ci.ProviderConfig{IsPR: true, PR: "12", PRBranch: "feature", Branch: "master", Tag: "", Commit: "190bfd6aa60022afd0ef830342cfb07e33c45f37", BuildURL: "https://bitbucket.org/owner/repo/addon/pipelines/home#!/results/8", Service: "bitbucket", Name: "Bitbucket Pipelines"},
+			hasError: false,
+		},
+		{
+			service: "Azure Pipelines Push",
+			envs: map[string]string{
+				"TF_BUILD":             "True",
+				"BUILD_SOURCEVERSION":  "190bfd6aa60022afd0ef830342cfb07e33c45f37",
+				"BUILD_SOURCEBRANCH":   "refs/heads/main",
+				"BUILD_BUILDID":        "42",
+				"SYSTEM_COLLECTIONURI": "https://dev.azure.com/org/",
+				"SYSTEM_TEAMPROJECT":   "project",
+			},
+			result:   &ci.ProviderConfig{IsPR: false, PR: "", PRBranch: "", Branch: "main", Tag: "", Commit: "190bfd6aa60022afd0ef830342cfb07e33c45f37", BuildURL: "https://dev.azure.com/org/project/_build/results?buildId=42", Service: "azure", Name: "Azure Pipelines"},
+			hasError: false,
+		},
+		{
+			service: "Azure Pipelines PR",
+			envs: map[string]string{
+				"TF_BUILD":                         "True",
+				"BUILD_SOURCEVERSION":              "190bfd6aa60022afd0ef830342cfb07e33c45f37",
+				"BUILD_SOURCEBRANCH":               "refs/pull/3/merge",
+				"BUILD_BUILDID":                    "43",
+				"SYSTEM_COLLECTIONURI":             "https://dev.azure.com/org/",
+				"SYSTEM_TEAMPROJECT":               "project",
+				"SYSTEM_PULLREQUEST_PULLREQUESTID": "3",
+				"SYSTEM_PULLREQUEST_SOURCEBRANCH":  "refs/heads/feature",
+				"SYSTEM_PULLREQUEST_TARGETBRANCH":  "refs/heads/main",
+			},
+			result:   &ci.ProviderConfig{IsPR: true, PR: "3", PRBranch: "feature", Branch: "main", Tag: "", Commit: "190bfd6aa60022afd0ef830342cfb07e33c45f37", BuildURL: "https://dev.azure.com/org/project/_build/results?buildId=43", Service: "azure", Name: "Azure Pipelines"},
+			hasError: false,
+		},
 	}
 
 	for _, config := range testConfigs {
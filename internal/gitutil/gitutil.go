@@ -4,6 +4,7 @@ package gitutil
 import (
 	"fmt"
 	"sort"
+	"strings"
 
 	"github.com/pkg/errors"
 
@@ -12,22 +13,26 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
 	log "github.com/sirupsen/logrus"
 )
 
 // GitUtil struct
 type GitUtil struct {
 	Repository *git.Repository
+	// TagPrefix of version tags, tags without this prefix are ignored
+	TagPrefix string
 }
 
 // New GitUtil struct and open git repository
-func New(folder string) (*GitUtil, error) {
+func New(folder, tagPrefix string) (*GitUtil, error) {
 	r, err := git.PlainOpen(folder)
 	if err != nil {
 		return nil, err
 	}
 	utils := &GitUtil{
 		Repository: r,
+		TagPrefix:  tagPrefix,
 	}
 	return utils, nil
 
@@ -76,26 +81,77 @@ func (g *GitUtil) GetBranch() (string, error) {
 	return ref.Name().Short(), nil
 }
 
-// GetLastVersion from git tags
-func (g *GitUtil) GetVersion(version string) (*semver.Version, *plumbing.Reference, error) {
-
+// parseTag returns the version of a tag with the configured prefix
+func (g *GitUtil) parseTag(name string) (*semver.Version, bool) {
+	if !strings.HasPrefix(name, g.TagPrefix) {
+		return nil, false
+	}
+	version := strings.TrimPrefix(name, g.TagPrefix)
+	if version == "" || version[0] < '0' || version[0] > '9' {
+		return nil, false
+	}
 	v, err := semver.NewVersion(version)
 	if err != nil {
-		return nil, nil, err
+		return nil, false
+	}
+	return v, true
+}
+
+// GetVersion from git tags, version can be given with or without the tag prefix
+func (g *GitUtil) GetVersion(version string) (*semver.Version, *plumbing.Reference, error) {
+
+	tagName := version
+	if _, ok := g.parseTag(tagName); !ok {
+		tagName = g.TagPrefix + version
+	}
+
+	v, ok := g.parseTag(tagName)
+	if !ok {
+		return nil, nil, fmt.Errorf("%s is not a valid version with tag prefix \"%s\"", version, g.TagPrefix)
+	}
+
+	tag, err := g.Repository.Tag(tagName)
+	if err == git.ErrTagNotFound {
+		// calendar versions can be zero padded, like 2024.05.0 for 2024.5.0
+		tag, err = g.findTag(v)
 	}
-	tag, err := g.Repository.Tag(version)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, errors.Wrapf(err, "could not find tag %s", tagName)
 	}
 
 	log.Debugf("Found old hash %s", tag.Hash().String())
 	return v, tag, nil
 }
 
+// findTag with the same version but a different format
+func (g *GitUtil) findTag(version *semver.Version) (*plumbing.Reference, error) {
+	gitTags, err := g.Repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var tag *plumbing.Reference
+	err = gitTags.ForEach(func(p *plumbing.Reference) error {
+		if v, ok := g.parseTag(p.Name().Short()); ok && v.Equal(version) {
+			tag = p
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tag == nil {
+		return nil, git.ErrTagNotFound
+	}
+	return tag, nil
+}
+
 // GetLastVersion from git tags
 func (g *GitUtil) GetLastVersion() (*semver.Version, *plumbing.Reference, error) {
 
 	var tags []*semver.Version
+	tagNames := map[*semver.Version]string{}
 
 	gitTags, err := g.Repository.Tags()
 
@@ -103,15 +159,33 @@ func (g *GitUtil) GetLastVersion() (*semver.Version, *plumbing.Reference, error)
 		return nil, nil, err
 	}
 
+	reachable, err := g.reachableFromHead()
+	if err != nil {
+		return nil, nil, err
+	}
+
 	err = gitTags.ForEach(func(p *plumbing.Reference) error {
-		v, err := semver.NewVersion(p.Name().Short())
 		log.Tracef("Tag %+v with hash: %s", p.Name().Short(), p.Hash())
 
-		if err == nil {
-			tags = append(tags, v)
-		} else {
-			log.Debugf("Tag %s is not a valid version, skip", p.Name().Short())
+		v, ok := g.parseTag(p.Name().Short())
+		if !ok {
+			log.Debugf("Tag %s is not a valid version with tag prefix \"%s\", skip", p.Name().Short(), g.TagPrefix)
+			return nil
+		}
+
+		commit, err := g.tagCommit(p)
+		if err != nil {
+			log.Debugf("Tag %s does not point to a commit, skip: %s", p.Name().Short(), err.Error())
+			return nil
+		}
+
+		if _, ok := reachable[commit.Hash]; !ok {
+			log.Debugf("Tag %s is not reachable from HEAD, skip", p.Name().Short())
+			return nil
 		}
+
+		tags = append(tags, v)
+		tagNames[v] = p.Name().Short()
 		return nil
 	})
 
@@ -128,7 +202,7 @@ func (g *GitUtil) GetLastVersion() (*semver.Version, *plumbing.Reference, error)
 
 	log.Debugf("Found old version %s", tags[0].String())
 
-	tag, err := g.Repository.Tag(tags[0].Original())
+	tag, err := g.Repository.Tag(tagNames[tags[0]])
 	if err != nil {
 		return nil, nil, err
 	}
@@ -137,6 +211,64 @@ func (g *GitUtil) GetLastVersion() (*semver.Version, *plumbing.Reference, error)
 	return tags[0], tag, nil
 }
 
+// GetVersions of all tags with the tag prefix, also tags not reachable from HEAD
+func (g *GitUtil) GetVersions() ([]*semver.Version, error) {
+	gitTags, err := g.Repository.Tags()
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []*semver.Version
+	err = gitTags.ForEach(func(p *plumbing.Reference) error {
+		if v, ok := g.parseTag(p.Name().Short()); ok {
+			versions = append(versions, v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Sort(sort.Reverse(semver.Collection(versions)))
+	return versions, nil
+}
+
+// tagCommit returns the commit of a lightweight or annotated tag
+func (g *GitUtil) tagCommit(tag *plumbing.Reference) (*object.Commit, error) {
+	tagObject, err := g.Repository.TagObject(tag.Hash())
+	switch err {
+	case nil:
+		return tagObject.Commit()
+	case plumbing.ErrObjectNotFound:
+		return g.Repository.CommitObject(tag.Hash())
+	default:
+		return nil, err
+	}
+}
+
+// reachableFromHead returns all commits which are ancestors of HEAD, including HEAD
+func (g *GitUtil) reachableFromHead() (map[plumbing.Hash]struct{}, error) {
+	ref, err := g.Repository.Head()
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := g.Repository.Log(&git.LogOptions{From: ref.Hash()})
+	if err != nil {
+		return nil, fmt.Errorf("could not get git log %w", err)
+	}
+
+	reachable := map[plumbing.Hash]struct{}{}
+	err = iter.ForEach(func(c *object.Commit) error {
+		reachable[c.Hash] = struct{}{}
+		return nil
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Could not read commits, check git clone depth in your ci")
+	}
+	return reachable, nil
+}
+
 // GetCommits from git hash to HEAD
 func (g *GitUtil) GetCommits(lastTagHash *plumbing.Reference) ([]shared.Commit, error) {
 
@@ -144,10 +276,38 @@ func (g *GitUtil) GetCommits(lastTagHash *plumbing.Reference) ([]shared.Commit,
 	if err != nil {
 		return nil, err
 	}
-	logOptions := &git.LogOptions{From: ref.Hash()}
+	return g.getCommits(ref.Hash(), lastTagHash)
+}
+
+// GetCommitsOfTag from git hash to the commit of the tag
+func (g *GitUtil) GetCommitsOfTag(tag, lastTagHash *plumbing.Reference) ([]shared.Commit, error) {
+	tagCommit, err := g.tagCommit(tag)
+	if err != nil {
+		return nil, err
+	}
+	return g.getCommits(tagCommit.Hash, lastTagHash)
+}
+
+// GetCommitsTill from git hash to the given commit
+func (g *GitUtil) GetCommitsTill(commit string, lastTagHash *plumbing.Reference) ([]shared.Commit, error) {
+	if !plumbing.IsHash(commit) {
+		return nil, fmt.Errorf("%s is not a commit hash", commit)
+	}
+	if _, err := g.Repository.CommitObject(plumbing.NewHash(commit)); err != nil {
+		return nil, errors.Wrapf(err, "could not find commit %s", commit)
+	}
+	return g.getCommits(plumbing.NewHash(commit), lastTagHash)
+}
+
+func (g *GitUtil) getCommits(from plumbing.Hash, lastTagHash *plumbing.Reference) ([]shared.Commit, error) {
+	logOptions := &git.LogOptions{From: from}
 
 	if lastTagHash != nil {
-		logOptions = &git.LogOptions{From: lastTagHash.Hash()}
+		lastTagCommit, err := g.tagCommit(lastTagHash)
+		if err != nil {
+			return nil, err
+		}
+		logOptions = &git.LogOptions{From: lastTagCommit.Hash}
 	}
 	excludeIter, err := g.Repository.Log(logOptions)
 	if err != nil {
@@ -168,32 +328,36 @@ func (g *GitUtil) GetCommits(lastTagHash *plumbing.Reference) ([]shared.Commit,
 		return !ok && len(commit.ParentHashes) < 2
 	}
 
-	startCommit, err := g.Repository.CommitObject(ref.Hash())
+	startCommit, err := g.Repository.CommitObject(from)
 	if err != nil {
 		return nil, err
 	}
 	cIter := object.NewFilterCommitIter(startCommit, &isValid, nil)
 
-	commits := make(map[string]shared.Commit)
+	commits := make([]shared.Commit, 0)
 
 	err = cIter.ForEach(func(c *object.Commit) error {
 		log.Debugf("Found commit with hash %s from %s", c.Hash.String(), c.Author.Name)
-		commits[c.Hash.String()] = shared.Commit{
-			Message: c.Message,
-			Author:  c.Author.Name,
-			Hash:    c.Hash.String(),
-		}
+		commits = append(commits, shared.Commit{
+			Message:        c.Message,
+			Author:         c.Author.Name,
+			AuthorEmail:    c.Author.Email,
+			AuthorDate:     c.Author.When,
+			Committer:      c.Committer.Name,
+			CommitterEmail: c.Committer.Email,
+			CommitterDate:  c.Committer.When,
+			Hash:           c.Hash.String(),
+		})
 		return nil
 	})
 	if err != nil {
 		return nil, errors.Wrap(err, "Could not read commits, check git clone depth in your ci")
 	}
 
-	l := make([]shared.Commit, 0)
-
-	for _, value := range commits {
-		l = append(l, value)
-	}
+	// newest commit first, commits with the same date keep the order of the history walk
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].CommitterDate.After(commits[j].CommitterDate)
+	})
 
-	return l, nil
+	return commits, nil
 }
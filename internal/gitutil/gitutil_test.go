@@ -0,0 +1,284 @@
+package gitutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Nightapes/go-semantic-release/internal/gitutil"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRepository struct {
+	t          *testing.T
+	repository *git.Repository
+	worktree   *git.Worktree
+	date       time.Time
+}
+
+func newTestRepository(t *testing.T) *testRepository {
+	fs := memfs.New()
+	repository, err := git.Init(memory.NewStorage(), fs)
+	assert.NoError(t, err, "should open git repository")
+
+	file, err := fs.Create("README.md")
+	assert.NoError(t, err, "should create file")
+
+	w, err := repository.Worktree()
+	assert.NoError(t, err, "should get worktree")
+
+	_, err = w.Add(file.Name())
+	assert.NoError(t, err, "should add file")
+
+	return &testRepository{
+		t:          t,
+		repository: repository,
+		worktree:   w,
+		date:       time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func (r *testRepository) commit(message string) plumbing.Hash {
+	r.date = r.date.Add(time.Hour)
+	hash, err := r.worktree.Commit(message, &git.CommitOptions{
+		Author: &object.Signature{
+			Name:  "John Doe",
+			Email: "john@doe.org",
+			When:  r.date,
+		},
+		Committer: &object.Signature{
+			Name:  "Jane Doe",
+			Email: "jane@doe.org",
+			When:  r.date.Add(time.Minute),
+		},
+		AllowEmptyCommits: true,
+	})
+	assert.NoError(r.t, err, "should commit")
+	return hash
+}
+
+func (r *testRepository) tag(name string, hash plumbing.Hash) *plumbing.Reference {
+	ref, err := r.repository.CreateTag(name, hash, nil)
+	assert.NoError(r.t, err, "should create tag")
+	return ref
+}
+
+func TestGitUtil_GetCommits(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	repo.tag("v1.0.0", first)
+
+	hashes := []plumbing.Hash{
+		repo.commit("feat: second"),
+		repo.commit("fix: third"),
+		repo.commit("fix: fourth"),
+	}
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: "v"}
+	_, tag, err := util.GetLastVersion()
+	assert.NoError(t, err)
+
+	commits, err := util.GetCommits(tag)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 3)
+
+	for i, commit := range commits {
+		assert.Equal(t, hashes[len(hashes)-1-i].String(), commit.Hash)
+		assert.Equal(t, "John Doe", commit.Author)
+		assert.Equal(t, "john@doe.org", commit.AuthorEmail)
+		assert.Equal(t, "Jane Doe", commit.Committer)
+		assert.Equal(t, "jane@doe.org", commit.CommitterEmail)
+		assert.Equal(t, commit.AuthorDate.Add(time.Minute).Unix(), commit.CommitterDate.Unix())
+	}
+	assert.Equal(t, "fix: fourth", commits[0].Message)
+}
+
+func TestGitUtil_GetCommitsOfTag(t *testing.T) {
+	repo := newTestRepository(t)
+	repo.tag("v1.0.0", repo.commit("feat: first"))
+	second := repo.commit("feat: second")
+	repo.tag("v1.1.0", second)
+	repo.commit("fix: third")
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: "v"}
+	_, last, err := util.GetVersion("1.0.0")
+	assert.NoError(t, err)
+	_, tag, err := util.GetVersion("1.1.0")
+	assert.NoError(t, err)
+
+	commits, err := util.GetCommitsOfTag(tag, last)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, second.String(), commits[0].Hash)
+}
+
+func TestGitUtil_GetLastVersion(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	repo.tag("v9.0.0", first)
+	repo.tag("api/v1.2.0", first)
+	second := repo.commit("feat: second")
+	repo.tag("api/v1.3.0", second)
+	repo.tag("api/vfoo", second)
+	repo.tag("2.0.0", second)
+
+	testConfigs := []struct {
+		testCase  string
+		tagPrefix string
+		version   string
+		tag       string
+	}{
+		{
+			testCase:  "default prefix",
+			tagPrefix: "v",
+			version:   "9.0.0",
+			tag:       "v9.0.0",
+		},
+		{
+			testCase:  "custom prefix ignores other tags",
+			tagPrefix: "api/v",
+			version:   "1.3.0",
+			tag:       "api/v1.3.0",
+		},
+		{
+			testCase:  "empty prefix",
+			tagPrefix: "",
+			version:   "2.0.0",
+			tag:       "2.0.0",
+		},
+		{
+			testCase:  "no tags with prefix",
+			tagPrefix: "web/v",
+		},
+	}
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: test.tagPrefix}
+			version, tag, err := util.GetLastVersion()
+			assert.NoError(t, err)
+			if test.version == "" {
+				assert.Nil(t, version)
+				assert.Nil(t, tag)
+				return
+			}
+			assert.Equal(t, test.version, version.String())
+			assert.Equal(t, test.tag, tag.Name().Short())
+		})
+	}
+}
+
+func TestGitUtil_GetVersion(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	repo.tag("api/v1.2.0", first)
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: "api/v"}
+
+	for _, from := range []string{"1.2.0", "api/v1.2.0"} {
+		version, tag, err := util.GetVersion(from)
+		assert.NoError(t, err)
+		assert.Equal(t, "1.2.0", version.String())
+		assert.Equal(t, first, tag.Hash())
+	}
+
+	_, _, err := util.GetVersion("1.3.0")
+	assert.Error(t, err)
+
+	_, _, err = util.GetVersion("v1.2.0")
+	assert.Error(t, err)
+}
+
+func TestGitUtil_GetLastVersion_ReachableFromHead(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	_, err := repo.repository.CreateTag("v1.9.0", first, &git.CreateTagOptions{
+		Message: "Release v1.9.0",
+		Tagger:  &object.Signature{Name: "John Doe", Email: "john@doe.org", When: repo.date},
+	})
+	assert.NoError(t, err)
+
+	err = repo.worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("beta"), Create: true})
+	assert.NoError(t, err)
+	repo.tag("v2.0.0-beta.3", repo.commit("feat!: breaking on beta"))
+
+	err = repo.worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.Master})
+	assert.NoError(t, err)
+	second := repo.commit("fix: on master")
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: "v"}
+	version, tag, err := util.GetLastVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.9.0", version.String())
+	assert.Equal(t, "v1.9.0", tag.Name().Short())
+
+	commits, err := util.GetCommits(tag)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, second.String(), commits[0].Hash)
+}
+
+func TestGitUtil_GetLastVersion_SkipTagsWithoutCommit(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	repo.tag("v1.0.0", first)
+
+	commit, err := repo.repository.CommitObject(first)
+	assert.NoError(t, err)
+	// a tag on a tree and a tag on a commit missing in a shallow clone
+	assert.NoError(t, repo.repository.Storer.SetReference(plumbing.NewHashReference("refs/tags/v3.0.0", commit.TreeHash)))
+	assert.NoError(t, repo.repository.Storer.SetReference(plumbing.NewHashReference("refs/tags/v4.0.0", plumbing.NewHash("1234567890123456789012345678901234567890"))))
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: "v"}
+	version, tag, err := util.GetLastVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0.0", version.String())
+	assert.Equal(t, "v1.0.0", tag.Name().Short())
+}
+
+func TestGitUtil_GetVersion_CalVer(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	repo.tag("2024.05.0", first)
+	second := repo.commit("fix: second")
+	repo.tag("2024.05.1", second)
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: ""}
+
+	version, tag, err := util.GetLastVersion()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024.05.1", version.Original())
+	assert.Equal(t, "2024.05.1", tag.Name().Short())
+
+	for _, from := range []string{"2024.05.0", "2024.5.0"} {
+		version, tag, err := util.GetVersion(from)
+		assert.NoError(t, err)
+		assert.Equal(t, "2024.5.0", version.String())
+		assert.Equal(t, first, tag.Hash())
+	}
+}
+
+func TestGitUtil_GetVersions(t *testing.T) {
+	repo := newTestRepository(t)
+	first := repo.commit("feat: first")
+	repo.tag("v1.3.0-beta.0", first)
+	repo.tag("api/v2.0.0", first)
+
+	err := repo.worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.NewBranchReferenceName("beta"), Create: true})
+	assert.NoError(t, err)
+	repo.tag("v1.3.0-beta.1", repo.commit("feat: on beta"))
+
+	err = repo.worktree.Checkout(&git.CheckoutOptions{Branch: plumbing.Master})
+	assert.NoError(t, err)
+
+	util := &gitutil.GitUtil{Repository: repo.repository, TagPrefix: "v"}
+	versions, err := util.GetVersions()
+	assert.NoError(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "1.3.0-beta.1", versions[0].String())
+	assert.Equal(t, "1.3.0-beta.0", versions[1].String())
+}
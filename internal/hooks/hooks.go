@@ -53,9 +53,27 @@ func (h *Hooks) PostRelease() error {
 	return nil
 }
 
+// PreReleaseCommands with the release version, the commands are not run
+func (h *Hooks) PreReleaseCommands() []string {
+	return h.replaceVersion(h.config.Hooks.PreRelease)
+}
+
+// PostReleaseCommands with the release version, the commands are not run
+func (h *Hooks) PostReleaseCommands() []string {
+	return h.replaceVersion(h.config.Hooks.PostRelease)
+}
+
+func (h *Hooks) replaceVersion(commands []string) []string {
+	replaced := make([]string, 0, len(commands))
+	for _, command := range commands {
+		replaced = append(replaced, strings.ReplaceAll(command, "$RELEASE_VERSION", h.version.Next.String()))
+	}
+	return replaced
+}
+
 func (h *Hooks) runCommand(command string) error {
 
-	cmdReplaced := strings.ReplaceAll(command, "$RELEASE_VERSION", h.version.Next.Version.String())
+	cmdReplaced := h.replaceVersion([]string{command})[0]
 
 	var cmd *exec.Cmd
 	if runtime.GOOS == "windows" {
@@ -65,7 +83,7 @@ func (h *Hooks) runCommand(command string) error {
 	}
 
 	cmd.Env = os.Environ()
-	cmd.Env = append(cmd.Env, "RELEASE_VERSION="+h.version.Next.Version.String())
+	cmd.Env = append(cmd.Env, "RELEASE_VERSION="+h.version.Next.String())
 	cmdReader, err := cmd.StdoutPipe()
 	if err != nil {
 		return err
@@ -89,6 +89,28 @@ func TestPostReleaseHooksError(t *testing.T) {
 	assert.Error(t, err)
 }
 
+func TestReleaseHooksCommands(t *testing.T) {
+
+	hooks := hooks.New(&config.ReleaseConfig{
+		Hooks: config.Hooks{
+			PreRelease: []string{
+				"exit 1",
+				"echo $RELEASE_VERSION",
+			},
+			PostRelease: []string{
+				"echo done $RELEASE_VERSION",
+			},
+		},
+	},
+		&shared.ReleaseVersion{
+			Next: shared.ReleaseVersionEntry{
+				Version: createVersion("1.0.0"),
+			},
+		})
+	assert.Equal(t, []string{"exit 1", "echo 1.0.0"}, hooks.PreReleaseCommands())
+	assert.Equal(t, []string{"echo done 1.0.0"}, hooks.PostReleaseCommands())
+}
+
 func TestHelperProcess(t *testing.T) {
 	if os.Getenv("GO_WANT_HELPER_PROCESS") != "1" {
 
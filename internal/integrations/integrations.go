@@ -1,8 +1,11 @@
 package integrations
 
 import (
+	"strings"
+
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/pmezard/go-difflib/difflib"
 )
 
 // Integrations struct
@@ -24,3 +27,39 @@ func (i Integrations) Run() error {
 	}
 	return nil
 }
+
+// Files changed by the integrations with their new content, nothing is written
+func (i Integrations) Files() ([]shared.ReleaseFile, error) {
+	files := []shared.ReleaseFile{}
+	if i.config.NPM.Enabled {
+		path, _, newData, err := i.npmChange()
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, shared.ReleaseFile{Path: path, Content: newData})
+	}
+	return files, nil
+}
+
+// Diff of all files the integrations would change, nothing is written
+func (i Integrations) Diff() (string, error) {
+	diffs := []string{}
+	if i.config.NPM.Enabled {
+		path, oldData, newData, err := i.npmChange()
+		if err != nil {
+			return "", err
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        difflib.SplitLines(string(oldData)),
+			B:        difflib.SplitLines(string(newData)),
+			FromFile: path,
+			ToFile:   path,
+			Context:  3,
+		})
+		if err != nil {
+			return "", err
+		}
+		diffs = append(diffs, diff)
+	}
+	return strings.Join(diffs, "\n"), nil
+}
@@ -7,6 +7,15 @@ import (
 )
 
 func (i *Integrations) updateNPM() error {
+	path, _, newData, err := i.npmChange()
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, newData, 0777)
+}
+
+// npmChange returns the path of the package.json with its current and updated content
+func (i *Integrations) npmChange() (string, []byte, []byte, error) {
 
 	npmConfig := i.config.NPM
 	if npmConfig.Path == "" {
@@ -16,13 +25,13 @@ func (i *Integrations) updateNPM() error {
 	log.Debugf("Set version %s to %s", i.version.Next.Version, npmConfig.Path)
 	data, err := ioutil.ReadFile(npmConfig.Path)
 	if err != nil {
-		return err
+		return "", nil, nil, err
 	}
 
 	newData, err := sjson.Set(string(data), "version", i.version.Next.Version)
 	if err != nil {
-		return err
+		return "", nil, nil, err
 	}
 
-	return ioutil.WriteFile(npmConfig.Path, []byte(newData), 0777)
+	return npmConfig.Path, data, []byte(newData), nil
 }
@@ -60,3 +60,62 @@ func TestIntegrations_updateNPM(t *testing.T) {
 }`, string(updatedFile))
 
 }
+
+func TestIntegrations_Diff(t *testing.T) {
+	file, err := ioutil.TempFile("", "package")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+
+	content := `{
+"name": "test",
+"version": "0.0.0",
+"license": "MIT"
+}`
+	err = ioutil.WriteFile(file.Name(), []byte(content), 0777)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testVersion, err := semver.NewVersion("1.2.0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	i := New(&config.Integrations{NPM: config.IntegrationNPM{
+		Enabled: true,
+		Path:    file.Name(),
+	}}, &shared.ReleaseVersion{
+		Next: shared.ReleaseVersionEntry{
+			Version: testVersion,
+		},
+	})
+
+	diff, err := i.Diff()
+	assert.NoError(t, err)
+	assert.Equal(t, `--- `+file.Name()+`
++++ `+file.Name()+`
+@@ -1,5 +1,5 @@
+ {
+ "name": "test",
+-"version": "0.0.0",
++"version": "1.2.0",
+ "license": "MIT"
+ }
+`, diff)
+
+	files, err := i.Files()
+	assert.NoError(t, err)
+	assert.Equal(t, []shared.ReleaseFile{{Path: file.Name(), Content: []byte(`{
+"name": "test",
+"version": "1.2.0",
+"license": "MIT"
+}`)}}, files)
+
+	unchanged, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, content, string(unchanged))
+}
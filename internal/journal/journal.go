@@ -0,0 +1,111 @@
+// Package journal records the completed steps of a release, a rerun of the same version skips them
+package journal
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// Journal of a release version
+type Journal struct {
+	Version string `yaml:"version"`
+	// Commit which was analyzed for the release
+	Commit string `yaml:"commit"`
+	// ReleaseCommit created on top of the analyzed commit, HEAD of a rerun after the release commit
+	ReleaseCommit string   `yaml:"releaseCommit,omitempty"`
+	Steps         []string `yaml:"steps"`
+	path          string
+}
+
+// Read the journal from .release, a new journal is returned if it belongs to another version
+func Read(repository string, releaseVersion *shared.ReleaseVersion) (*Journal, error) {
+	completePath := path.Join(path.Dir(repository), ".release")
+	journal := &Journal{
+		Version: releaseVersion.Next.String(),
+		Commit:  releaseVersion.Next.Commit,
+		Steps:   []string{},
+		path:    completePath,
+	}
+
+	content, err := ioutil.ReadFile(completePath)
+	if os.IsNotExist(err) {
+		return journal, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var parsedContent Journal
+	if err := yaml.Unmarshal(content, &parsedContent); err != nil {
+		return nil, err
+	}
+
+	sameCommit := parsedContent.Commit == journal.Commit || (parsedContent.ReleaseCommit != "" && parsedContent.ReleaseCommit == journal.Commit)
+	if parsedContent.Version != journal.Version || !sameCommit {
+		log.Debugf("Ignore journal %s of version %s", completePath, parsedContent.Version)
+		return journal, nil
+	}
+
+	log.Infof("Found journal of version %s, completed steps %v", journal.Version, parsedContent.Steps)
+	journal.Commit = parsedContent.Commit
+	journal.ReleaseCommit = parsedContent.ReleaseCommit
+	journal.Steps = parsedContent.Steps
+	return journal, nil
+}
+
+// IsDone returns true if the step was completed, always false for a nil journal
+func (j *Journal) IsDone(step string) bool {
+	if j == nil {
+		return false
+	}
+	for _, done := range j.Steps {
+		if done == step {
+			return true
+		}
+	}
+	return false
+}
+
+// Done saves the step as completed, a nil journal saves nothing
+func (j *Journal) Done(step string) error {
+	if j == nil || j.IsDone(step) {
+		return nil
+	}
+	j.Steps = append(j.Steps, step)
+	return j.save()
+}
+
+// SetReleaseCommit saves the hash of the release commit, a nil journal saves nothing
+func (j *Journal) SetReleaseCommit(hash string) error {
+	if j == nil {
+		return nil
+	}
+	j.ReleaseCommit = hash
+	return j.save()
+}
+
+func (j *Journal) save() error {
+	data, err := yaml.Marshal(j)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(j.path, data, 0644)
+}
+
+// Run the step if it is not completed yet and save it as completed on success
+func (j *Journal) Run(step string, run func() error) error {
+	if j.IsDone(step) {
+		log.Infof("Skip %s, already done for version %s", step, j.Version)
+		return nil
+	}
+	if err := run(); err != nil {
+		return err
+	}
+	return j.Done(step)
+}
@@ -0,0 +1,76 @@
+package journal_test
+
+import (
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/Nightapes/go-semantic-release/internal/journal"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal(t *testing.T) {
+	repository := path.Join(t.TempDir(), "repo")
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0"), Commit: "abc"}}
+
+	j, err := journal.Read(repository, releaseVersion)
+	assert.NoError(t, err)
+	assert.False(t, j.IsDone("hooks"))
+
+	assert.NoError(t, j.Run("hooks", func() error { return nil }))
+	assert.Error(t, j.Run("release", func() error { return fmt.Errorf("upload failed") }))
+
+	j, err = journal.Read(repository, releaseVersion)
+	assert.NoError(t, err)
+	assert.True(t, j.IsDone("hooks"))
+	assert.False(t, j.IsDone("release"))
+
+	runs := 0
+	assert.NoError(t, j.Run("hooks", func() error {
+		runs++
+		return nil
+	}))
+	assert.Equal(t, 0, runs)
+
+	nextVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.1.0"), Commit: "def"}}
+	j, err = journal.Read(repository, nextVersion)
+	assert.NoError(t, err)
+	assert.Empty(t, j.Steps)
+}
+
+func TestJournal_Nil(t *testing.T) {
+	var j *journal.Journal
+	assert.False(t, j.IsDone("hooks"))
+	assert.NoError(t, j.Done("hooks"))
+
+	runs := 0
+	assert.NoError(t, j.Run("hooks", func() error {
+		runs++
+		return nil
+	}))
+	assert.Equal(t, 1, runs)
+}
+
+func TestJournal_ReleaseCommit(t *testing.T) {
+	repository := path.Join(t.TempDir(), "repo")
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0"), Commit: "abc"}}
+
+	j, err := journal.Read(repository, releaseVersion)
+	assert.NoError(t, err)
+	assert.NoError(t, j.Run("release commit", func() error { return j.SetReleaseCommit("def") }))
+
+	// the release commit is HEAD of the rerun
+	rerun := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0"), Commit: "def"}}
+	j, err = journal.Read(repository, rerun)
+	assert.NoError(t, err)
+	assert.True(t, j.IsDone("release commit"))
+	assert.Equal(t, "abc", j.Commit)
+	assert.Equal(t, "def", j.ReleaseCommit)
+
+	other := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0"), Commit: "ghi"}}
+	j, err = journal.Read(repository, other)
+	assert.NoError(t, err)
+	assert.Empty(t, j.Steps)
+}
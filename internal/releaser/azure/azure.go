@@ -0,0 +1,143 @@
+package azure
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// AZURE identifer for azure devops interface
+const AZURE = "azure"
+
+const apiVersion = "7.0"
+
+// Client type struct
+type Client struct {
+	config  *config.AzureProvider
+	client  *http.Client
+	baseURL string
+	apiURL  string
+	log     *log.Entry
+}
+
+// New initialize a new azure devops release, calls are only recorded with dryRun
+func New(config *config.AzureProvider, checkConfig bool, dryRun *util.DryRun) (*Client, error) {
+	accessToken, err := util.GetAccessToken("AZURE_DEVOPS_TOKEN")
+	if err != nil && checkConfig {
+		return nil, err
+	}
+	config.AccessToken = accessToken
+
+	// personal access tokens and System.AccessToken are sent as password with an empty user
+	basicAuth := base64.StdEncoding.EncodeToString([]byte(":" + accessToken))
+	tokenHeader := util.NewAddHeaderTransport(dryRun.Transport(), "Authorization", "Basic "+basicAuth)
+	acceptHeader := util.NewAddHeaderTransport(tokenHeader, "Accept", "application/json")
+	contentHeader := util.NewAddHeaderTransport(acceptHeader, "Content-Type", "application/json")
+	httpClient := &http.Client{
+		Transport: contentHeader,
+		Timeout:   time.Second * 60,
+	}
+
+	logger := log.WithField("releaser", AZURE)
+
+	logger.Debugf("validate azure provider config")
+
+	if config.Organization == "" && config.CustomURL == "" && checkConfig {
+		return nil, fmt.Errorf("azure organization is not set")
+	}
+
+	if config.Project == "" && checkConfig {
+		return nil, fmt.Errorf("azure project is not set")
+	}
+
+	if config.Repo == "" && checkConfig {
+		return nil, fmt.Errorf("azure repo is not set")
+	}
+
+	// azure devops server uses the collection url, like https://server/tfs/collection
+	if config.CustomURL == "" {
+		config.CustomURL = "https://dev.azure.com/" + config.Organization
+	}
+	config.CustomURL = strings.TrimRight(config.CustomURL, "/")
+	logger.Debugf("Use azure url %s", config.CustomURL)
+
+	project := url.PathEscape(config.Project)
+	repo := url.PathEscape(config.Repo)
+
+	return &Client{
+		config:  config,
+		baseURL: fmt.Sprintf("%s/%s/_git/%s", config.CustomURL, project, repo),
+		apiURL:  fmt.Sprintf("%s/%s/_apis/git/repositories/%s", config.CustomURL, project, repo),
+		client:  httpClient,
+		log:     logger,
+	}, nil
+}
+
+// GetCommitURL for azure devops
+func (a *Client) GetCommitURL() string {
+	return fmt.Sprintf("%s/commit/{{hash}}", a.baseURL)
+}
+
+// GetCompareURL for azure devops, GT marks the versions as git tags
+func (a *Client) GetCompareURL(oldVersion, newVersion string) string {
+	return fmt.Sprintf("%s/branchCompare?baseVersion=GT%s&targetVersion=GT%s", a.baseURL, url.QueryEscape(oldVersion), url.QueryEscape(newVersion))
+}
+
+// PublishRelease does nothing, releases of azure devops are never drafts
+func (a *Client) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	a.log.Infof("Release %s is already published", releaseVersion.Next.String())
+	return false, nil
+}
+
+// CreateRelease creates an annotated tag with the changelog as message, azure repos have no releases and assets are not uploaded
+func (a *Client) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog, _ *assets.Set) error {
+	tagPrefix := config.DefaultTagPrefix
+	if a.config.TagPrefix != nil {
+		tagPrefix = *a.config.TagPrefix
+	}
+	tag := tagPrefix + releaseVersion.Next.String()
+	a.log.Infof("create tag %s for commit %s", tag, releaseVersion.Next.Commit)
+
+	bodyBytes, err := json.Marshal(AnnotatedTag{
+		Name:         tag,
+		TaggedObject: TaggedObject{ObjectID: releaseVersion.Next.Commit},
+		Message:      generatedChangelog.Title + "\n\n" + generatedChangelog.Content,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", fmt.Sprintf("%s/annotatedtags?api-version=%s", a.apiURL, apiVersion), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	resp, err := util.Do(a.client, req, nil)
+	if err != nil {
+		return fmt.Errorf("could not create tag: %s", err.Error())
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		a.log.Infof("A tag %s already exits, the release is complete", tag)
+		return nil
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return err
+	}
+
+	a.log.Infof("Created tag %s", tag)
+	return nil
+}
@@ -0,0 +1,122 @@
+package azure
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+)
+
+func TestGetCommitURL(t *testing.T) {
+	os.Setenv("AZURE_DEVOPS_TOKEN", "XXX")
+	defer os.Unsetenv("AZURE_DEVOPS_TOKEN")
+	client, err := New(&config.AzureProvider{
+		Organization: "org",
+		Project:      "project",
+		Repo:         "repo",
+	}, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.azure.com/org/project/_git/repo/commit/{{hash}}", client.GetCommitURL())
+}
+
+func TestGetCompareURL(t *testing.T) {
+	os.Setenv("AZURE_DEVOPS_TOKEN", "XXX")
+	defer os.Unsetenv("AZURE_DEVOPS_TOKEN")
+	client, err := New(&config.AzureProvider{
+		Organization: "org",
+		Project:      "my project",
+		Repo:         "repo",
+	}, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://dev.azure.com/org/my%20project/_git/repo/branchCompare?baseVersion=GTv1.0.0&targetVersion=GTv1.0.1", client.GetCompareURL("v1.0.0", "v1.0.1"))
+}
+
+func TestValidateConfig(t *testing.T) {
+	os.Setenv("AZURE_DEVOPS_TOKEN", "XXX")
+	defer os.Unsetenv("AZURE_DEVOPS_TOKEN")
+
+	for _, c := range []config.AzureProvider{
+		{Project: "project", Repo: "repo"},
+		{Organization: "org", Repo: "repo"},
+		{Organization: "org", Project: "project"},
+	} {
+		_, err := New(&c, true, nil)
+		assert.Error(t, err)
+	}
+
+	c := &config.AzureProvider{CustomURL: "https://server/tfs/collection/", Project: "project", Repo: "repo"}
+	client, err := New(c, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://server/tfs/collection/project/_git/repo/commit/{{hash}}", client.GetCommitURL())
+}
+
+func TestCreateRelease(t *testing.T) {
+
+	lastVersion, _ := semver.NewVersion("1.0.0")
+	newVersion, _ := semver.NewVersion("2.0.0")
+
+	testReleases := []struct {
+		responseCode int
+		valid        bool
+	}{
+		{responseCode: 201, valid: true},
+		{responseCode: 409, valid: true},
+		{responseCode: 403, valid: false},
+	}
+
+	for _, testObject := range testReleases {
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+
+			log.Infof("Got call from %s %s", req.Method, req.URL.String())
+
+			assert.Equal(t, "Basic "+base64.StdEncoding.EncodeToString([]byte(":aToken")), req.Header.Get("Authorization"))
+			assert.Equal(t, "POST", req.Method)
+			assert.Equal(t, "/org/project/_apis/git/repositories/repo/annotatedtags?api-version=7.0", req.URL.String())
+
+			bodyBytes, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, `{"name":"v2.0.0","taggedObject":{"objectId":"bar"},"message":"title\n\ncontent"}`, string(bodyBytes))
+
+			rw.WriteHeader(testObject.responseCode)
+		}))
+
+		os.Setenv("AZURE_DEVOPS_TOKEN", "aToken")
+		client, err := New(&config.AzureProvider{
+			CustomURL: testServer.URL + "/org",
+			Project:   "project",
+			Repo:      "repo",
+		}, true, nil)
+		assert.NoError(t, err)
+
+		err = client.CreateRelease(&shared.ReleaseVersion{
+			Last: shared.ReleaseVersionEntry{
+				Version: lastVersion,
+				Commit:  "foo",
+			},
+			Next: shared.ReleaseVersionEntry{
+				Version: newVersion,
+				Commit:  "bar",
+			},
+			Branch: "master",
+		}, &shared.GeneratedChangelog{
+			Title:   "title",
+			Content: "content",
+		}, nil)
+		if err != nil {
+			t.Log(err)
+		}
+		assert.Equal(t, testObject.valid, err == nil)
+
+		testServer.Close()
+		os.Unsetenv("AZURE_DEVOPS_TOKEN")
+	}
+}
@@ -0,0 +1,13 @@
+package azure
+
+// AnnotatedTag struct
+type AnnotatedTag struct {
+	Name         string       `json:"name"`
+	TaggedObject TaggedObject `json:"taggedObject"`
+	Message      string       `json:"message"`
+}
+
+// TaggedObject struct
+type TaggedObject struct {
+	ObjectID string `json:"objectId"`
+}
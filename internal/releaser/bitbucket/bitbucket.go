@@ -0,0 +1,230 @@
+package bitbucket
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// BITBUCKET identifer for bitbucket server and data center interface
+const BITBUCKET = "bitbucket"
+
+// Client type struct
+type Client struct {
+	config  *config.BitbucketProvider
+	client  *http.Client
+	baseURL string
+	apiURL  string
+	log     *log.Entry
+}
+
+// New initialize a new bitbucket release, calls are only recorded with dryRun
+func New(config *config.BitbucketProvider, checkConfig bool, dryRun *util.DryRun) (*Client, error) {
+	accessToken, err := util.GetAccessToken(fmt.Sprintf("%s_TOKEN", strings.ToUpper(BITBUCKET)))
+	if err != nil && checkConfig {
+		return nil, err
+	}
+	config.AccessToken = accessToken
+
+	tokenHeader := util.NewAddHeaderTransport(dryRun.Transport(), "Authorization", "Bearer "+accessToken)
+	acceptHeader := util.NewAddHeaderTransport(tokenHeader, "Accept", "application/json")
+	// bitbucket rejects multipart requests without this header (XSRF check)
+	checkHeader := util.NewAddHeaderTransport(acceptHeader, "X-Atlassian-Token", "no-check")
+	httpClient := &http.Client{
+		Transport: checkHeader,
+		Timeout:   time.Second * 60,
+	}
+
+	logger := log.WithField("releaser", BITBUCKET)
+
+	logger.Debugf("validate bitbucket provider config")
+
+	if config.Project == "" && checkConfig {
+		return nil, fmt.Errorf("bitbucket project is not set")
+	}
+
+	if config.Repo == "" && checkConfig {
+		return nil, fmt.Errorf("bitbucket repo is not set")
+	}
+
+	if config.CustomURL == "" && checkConfig {
+		return nil, fmt.Errorf("bitbucket customUrl is not set")
+	}
+
+	config.CustomURL = strings.TrimRight(config.CustomURL, "/")
+	logger.Debugf("Use bitbucket url %s", config.CustomURL)
+
+	return &Client{
+		config:  config,
+		baseURL: fmt.Sprintf("%s/projects/%s/repos/%s", config.CustomURL, config.Project, config.Repo),
+		apiURL:  fmt.Sprintf("%s/rest/api/1.0/projects/%s/repos/%s", config.CustomURL, config.Project, config.Repo),
+		client:  httpClient,
+		log:     logger,
+	}, nil
+}
+
+// GetCommitURL for bitbucket
+func (b *Client) GetCommitURL() string {
+	return fmt.Sprintf("%s/commits/{{hash}}", b.baseURL)
+}
+
+// GetCompareURL for bitbucket
+func (b *Client) GetCompareURL(oldVersion, newVersion string) string {
+	return fmt.Sprintf("%s/compare/diff?sourceBranch=%s&targetBranch=%s", b.baseURL, url.QueryEscape("refs/tags/"+newVersion), url.QueryEscape("refs/tags/"+oldVersion))
+}
+
+// PublishRelease does nothing, releases of bitbucket are never drafts
+func (b *Client) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	b.log.Infof("Release %s is already published", releaseVersion.Next.String())
+	return false, nil
+}
+
+// CreateRelease creates an annotated tag and publishes the changelog, bitbucket has no releases and assets are not uploaded
+func (b *Client) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog, _ *assets.Set) error {
+	tagPrefix := config.DefaultTagPrefix
+	if b.config.TagPrefix != nil {
+		tagPrefix = *b.config.TagPrefix
+	}
+	tag := tagPrefix + releaseVersion.Next.String()
+
+	message := generatedChangelog.Title + "\n\n" + generatedChangelog.Content
+	if b.config.ChangelogFile != "" {
+		message = generatedChangelog.Title
+	}
+
+	if err := b.createTag(tag, releaseVersion.Next.Commit, message); err != nil || b.config.ChangelogFile == "" {
+		return err
+	}
+
+	branch := b.config.ChangelogBranch
+	if branch == "" {
+		branch = releaseVersion.Branch
+	}
+	return b.writeChangelog(branch, tag, generatedChangelog.Content)
+}
+
+// createTag if it does not exist yet
+func (b *Client) createTag(tag, commit, message string) error {
+	b.log.Infof("create tag %s for commit %s", tag, commit)
+
+	bodyBytes, err := json.Marshal(Tag{
+		Name:       tag,
+		StartPoint: commit,
+		Message:    message,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", b.apiURL+"/tags", bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("could not create request: %s", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := util.Do(b.client, req, nil)
+	if err != nil {
+		return fmt.Errorf("could not create tag: %s", err.Error())
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		b.log.Infof("A tag %s already exits, will update a missing changelog", tag)
+		return nil
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return err
+	}
+
+	b.log.Infof("Created tag %s", tag)
+	return nil
+}
+
+// writeChangelog prepends the changelog to the file on the branch
+func (b *Client) writeChangelog(branch, tag, changelog string) error {
+	path := strings.Trim(b.config.ChangelogFile, "/")
+
+	existing := &bytes.Buffer{}
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/raw/%s?at=%s", b.apiURL, path, url.QueryEscape("refs/heads/"+branch)), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := util.Do(b.client, req, existing)
+	if err != nil {
+		return err
+	}
+
+	form := map[string]string{
+		"branch":  branch,
+		"message": fmt.Sprintf("docs(changelog): update %s for %s", path, tag),
+		"content": changelog,
+	}
+
+	switch resp.StatusCode {
+	case http.StatusNotFound:
+		b.log.Debugf("%s does not exist on branch %s, will create it", path, branch)
+	default:
+		if err := util.IsValidResult(resp); err != nil {
+			return err
+		}
+		if strings.HasPrefix(existing.String(), changelog) {
+			b.log.Infof("%s on branch %s already contains the changelog of %s", path, branch, tag)
+			return nil
+		}
+		form["content"] = changelog + "\n---\n\n" + existing.String()
+
+		commits := &CommitPage{}
+		req, err := http.NewRequest("GET", fmt.Sprintf("%s/commits?path=%s&until=%s&limit=1", b.apiURL, url.QueryEscape(path), url.QueryEscape("refs/heads/"+branch)), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := util.Do(b.client, req, commits)
+		if err != nil {
+			return err
+		}
+		if err := util.IsValidResult(resp); err != nil {
+			return err
+		}
+		if len(commits.Values) > 0 {
+			form["sourceCommitId"] = commits.Values[0].ID
+		}
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	for key, value := range form {
+		if err := w.WriteField(key, value); err != nil {
+			return err
+		}
+	}
+	w.Close()
+
+	req, err = http.NewRequest("PUT", fmt.Sprintf("%s/browse/%s", b.apiURL, path), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	resp, err = util.Do(b.client, req, nil)
+	if err != nil {
+		return fmt.Errorf("could not write changelog: %s", err.Error())
+	}
+	if err := util.IsValidResult(resp); err != nil {
+		return err
+	}
+
+	b.log.Infof("Updated %s on branch %s", path, branch)
+	return nil
+}
@@ -0,0 +1,198 @@
+package bitbucket
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+)
+
+func TestGetCommitURL(t *testing.T) {
+	os.Setenv("BITBUCKET_TOKEN", "XXX")
+	defer os.Unsetenv("BITBUCKET_TOKEN")
+	client, err := New(&config.BitbucketProvider{
+		CustomURL: "https://bitbucket.example.com/",
+		Project:   "KEY",
+		Repo:      "repo",
+	}, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo/commits/{{hash}}", client.GetCommitURL())
+}
+
+func TestGetCompareURL(t *testing.T) {
+	os.Setenv("BITBUCKET_TOKEN", "XXX")
+	defer os.Unsetenv("BITBUCKET_TOKEN")
+	client, err := New(&config.BitbucketProvider{
+		CustomURL: "https://bitbucket.example.com",
+		Project:   "KEY",
+		Repo:      "repo",
+	}, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://bitbucket.example.com/projects/KEY/repos/repo/compare/diff?sourceBranch=refs%2Ftags%2Fv1.0.1&targetBranch=refs%2Ftags%2Fv1.0.0", client.GetCompareURL("v1.0.0", "v1.0.1"))
+}
+
+func TestValidateConfig(t *testing.T) {
+	os.Setenv("BITBUCKET_TOKEN", "XXX")
+	defer os.Unsetenv("BITBUCKET_TOKEN")
+
+	for _, c := range []config.BitbucketProvider{
+		{Repo: "repo", CustomURL: "https://bitbucket.example.com"},
+		{Project: "KEY", CustomURL: "https://bitbucket.example.com"},
+		{Project: "KEY", Repo: "repo"},
+	} {
+		_, err := New(&c, true, nil)
+		assert.Error(t, err)
+	}
+}
+
+type testCall struct {
+	method       string
+	url          string
+	body         []string
+	responseCode int
+	responseBody string
+}
+
+func TestCreateRelease(t *testing.T) {
+
+	lastVersion, _ := semver.NewVersion("1.0.0")
+	newVersion, _ := semver.NewVersion("2.0.0")
+
+	testReleases := []struct {
+		testCase string
+		config   config.BitbucketProvider
+		calls    []testCall
+		valid    bool
+	}{
+		{
+			testCase: "changelog as tag message",
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", body: []string{`{"name":"v2.0.0","startPoint":"bar","message":"title\n\ncontent"}`}, responseCode: 200},
+			},
+			valid: true,
+		},
+		{
+			testCase: "tag exists",
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", responseCode: 409},
+			},
+			valid: true,
+		},
+		{
+			testCase: "tag exists, changelog file is not updated again",
+			config:   config.BitbucketProvider{ChangelogFile: "CHANGELOG.md"},
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", responseCode: 409},
+				{method: "GET", url: "/rest/api/1.0/projects/KEY/repos/repo/raw/CHANGELOG.md?at=refs%2Fheads%2Fmaster", responseCode: 200, responseBody: "content\n---\n\nold content"},
+			},
+			valid: true,
+		},
+		{
+			testCase: "tag exists, missing changelog is written",
+			config:   config.BitbucketProvider{ChangelogFile: "CHANGELOG.md"},
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", responseCode: 409},
+				{method: "GET", url: "/rest/api/1.0/projects/KEY/repos/repo/raw/CHANGELOG.md?at=refs%2Fheads%2Fmaster", responseCode: 404},
+				{method: "PUT", url: "/rest/api/1.0/projects/KEY/repos/repo/browse/CHANGELOG.md", body: []string{"content", "docs(changelog): update CHANGELOG.md for v2.0.0"}, responseCode: 200},
+			},
+			valid: true,
+		},
+		{
+			testCase: "tag fails",
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", responseCode: 500},
+			},
+			valid: false,
+		},
+		{
+			testCase: "new changelog file",
+			config:   config.BitbucketProvider{ChangelogFile: "CHANGELOG.md", ChangelogBranch: "main"},
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", body: []string{`{"name":"v2.0.0","startPoint":"bar","message":"title"}`}, responseCode: 200},
+				{method: "GET", url: "/rest/api/1.0/projects/KEY/repos/repo/raw/CHANGELOG.md?at=refs%2Fheads%2Fmain", responseCode: 404},
+				{method: "PUT", url: "/rest/api/1.0/projects/KEY/repos/repo/browse/CHANGELOG.md", body: []string{"content", "main", "docs(changelog): update CHANGELOG.md for v2.0.0"}, responseCode: 200},
+			},
+			valid: true,
+		},
+		{
+			testCase: "existing changelog file",
+			config:   config.BitbucketProvider{ChangelogFile: "CHANGELOG.md"},
+			calls: []testCall{
+				{method: "POST", url: "/rest/api/1.0/projects/KEY/repos/repo/tags", responseCode: 200},
+				{method: "GET", url: "/rest/api/1.0/projects/KEY/repos/repo/raw/CHANGELOG.md?at=refs%2Fheads%2Fmaster", responseCode: 200, responseBody: "old content"},
+				{method: "GET", url: "/rest/api/1.0/projects/KEY/repos/repo/commits?path=CHANGELOG.md&until=refs%2Fheads%2Fmaster&limit=1", responseCode: 200, responseBody: `{"values": [{"id": "abc123"}]}`},
+				{method: "PUT", url: "/rest/api/1.0/projects/KEY/repos/repo/browse/CHANGELOG.md", body: []string{"content\n---\n\nold content", "abc123"}, responseCode: 409},
+			},
+			valid: false,
+		},
+	}
+
+	for _, testObject := range testReleases {
+		t.Run(testObject.testCase, func(t *testing.T) {
+			calls := 0
+			testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+
+				log.Infof("Got call from %s %s", req.Method, req.URL.String())
+
+				if !assert.Less(t, calls, len(testObject.calls)) {
+					return
+				}
+				call := testObject.calls[calls]
+				calls++
+
+				assert.Equal(t, "Bearer aToken", req.Header.Get("Authorization"))
+				assert.Equal(t, call.method, req.Method)
+				assert.Equal(t, call.url, req.URL.String())
+
+				bodyBytes, err := ioutil.ReadAll(req.Body)
+				assert.NoError(t, err)
+				for _, body := range call.body {
+					assert.Contains(t, string(bodyBytes), body)
+				}
+
+				rw.WriteHeader(call.responseCode)
+				if _, err := rw.Write([]byte(call.responseBody)); err != nil {
+					log.Info(err)
+				}
+			}))
+			defer testServer.Close()
+
+			os.Setenv("BITBUCKET_TOKEN", "aToken")
+			defer os.Unsetenv("BITBUCKET_TOKEN")
+
+			testObject.config.CustomURL = testServer.URL
+			testObject.config.Project = "KEY"
+			testObject.config.Repo = "repo"
+			client, err := New(&testObject.config, true, nil)
+			assert.NoError(t, err)
+
+			err = client.CreateRelease(&shared.ReleaseVersion{
+				Last: shared.ReleaseVersionEntry{
+					Version: lastVersion,
+					Commit:  "foo",
+				},
+				Next: shared.ReleaseVersionEntry{
+					Version: newVersion,
+					Commit:  "bar",
+				},
+				Branch: "master",
+			}, &shared.GeneratedChangelog{
+				Title:   "title",
+				Content: "content",
+			}, nil)
+			if err != nil {
+				t.Log(err)
+			}
+			assert.Equal(t, testObject.valid, err == nil)
+			assert.Equal(t, len(testObject.calls), calls)
+		})
+	}
+}
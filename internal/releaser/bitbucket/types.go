@@ -0,0 +1,18 @@
+package bitbucket
+
+// Tag struct
+type Tag struct {
+	Name       string `json:"name"`
+	StartPoint string `json:"startPoint"`
+	Message    string `json:"message,omitempty"`
+}
+
+// Commit struct
+type Commit struct {
+	ID string `json:"id"`
+}
+
+// CommitPage struct
+type CommitPage struct {
+	Values []Commit `json:"values"`
+}
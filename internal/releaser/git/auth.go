@@ -0,0 +1,87 @@
+package git
+
+import (
+	"fmt"
+
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	gossh "golang.org/x/crypto/ssh"
+)
+
+// auth for pushing, basic auth over https or a ssh key from file or agent
+func (g *Client) auth() (transport.AuthMethod, error) {
+	if !g.config.SSH {
+		return &http.BasicAuth{
+			Username: g.config.Username,
+			Password: g.config.Auth,
+		}, nil
+	}
+
+	user, err := g.sshUser()
+	if err != nil {
+		return nil, err
+	}
+
+	hostKeyCallback, err := g.hostKeyCallback()
+	if err != nil {
+		return nil, err
+	}
+
+	if g.config.SSHKey == "" {
+		g.log.Debugf("Use ssh agent for user %s", user)
+		auth, err := ssh.NewSSHAgentAuth(user)
+		if err != nil {
+			return nil, fmt.Errorf("could not use ssh agent: %w", err)
+		}
+		auth.HostKeyCallback = hostKeyCallback
+		return auth, nil
+	}
+
+	g.log.Debugf("Use ssh key %s for user %s", g.config.SSHKey, user)
+	auth, err := ssh.NewPublicKeysFromFile(user, g.config.SSHKey, g.config.SSHKeyPassphrase)
+	if err != nil {
+		return nil, fmt.Errorf("could not read ssh key %s: %w", g.config.SSHKey, err)
+	}
+	auth.HostKeyCallback = hostKeyCallback
+	return auth, nil
+}
+
+// sshUser from the remote url like git@github.com:user/repo.git, default is git
+func (g *Client) sshUser() (string, error) {
+	url := g.config.RemoteURL
+	if url == "" {
+		remote, err := g.git.Repository.Remote(g.config.Remote)
+		if err != nil {
+			return "", fmt.Errorf("could not find remote %s: %w", g.config.Remote, err)
+		}
+		url = remote.Config().URLs[0]
+	}
+
+	endpoint, err := transport.NewEndpoint(url)
+	if err != nil {
+		return "", err
+	}
+	if endpoint.User == "" {
+		return "git", nil
+	}
+	return endpoint.User, nil
+}
+
+// hostKeyCallback verifies the host with known_hosts, without a configured file SSH_KNOWN_HOSTS or ~/.ssh/known_hosts is used
+func (g *Client) hostKeyCallback() (gossh.HostKeyCallback, error) {
+	if g.config.InsecureIgnoreHostKey {
+		g.log.Warnf("Host key of the remote is not verified, insecureIgnoreHostKey is set")
+		return gossh.InsecureIgnoreHostKey(), nil
+	}
+
+	files := []string{}
+	if g.config.KnownHosts != "" {
+		files = append(files, g.config.KnownHosts)
+	}
+	callback, err := ssh.NewKnownHostsCallback(files...)
+	if err != nil {
+		return nil, fmt.Errorf("could not read known hosts: %w", err)
+	}
+	return callback, nil
+}
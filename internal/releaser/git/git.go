@@ -2,32 +2,45 @@ package git
 
 import (
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/Nightapes/go-semantic-release/internal/assets"
 	"github.com/Nightapes/go-semantic-release/internal/gitutil"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
+	billyutil "github.com/go-git/go-billy/v5/util"
 	"github.com/go-git/go-git/v5"
 	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/object"
-	"github.com/go-git/go-git/v5/plumbing/transport/http"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
 	log "github.com/sirupsen/logrus"
 )
 
 // GITONLY identifer for git interface
 const GITONLY = "git"
 
+// defaultRemote for pushing tags if no remote is configured
+const defaultRemote = git.DefaultRemoteName
+
 // Client type struct
 type Client struct {
-	config *config.GitProvider
-	log    *log.Entry
-	git    *gitutil.GitUtil
+	config  *config.GitProvider
+	log     *log.Entry
+	git     *gitutil.GitUtil
+	dryRun  *util.DryRun
+	signKey *openpgp.Entity
+	// branch and hash of the release commit, the commit is pushed together with the tag
+	branch string
+	commit string
 }
 
-// New initialize a new gitRelease
-func New(config *config.GitProvider, git *gitutil.GitUtil, checkConfig bool) (*Client, error) {
+// New initialize a new gitRelease, tag and push are only recorded with dryRun
+func New(config *config.GitProvider, git *gitutil.GitUtil, checkConfig bool, dryRun *util.DryRun) (*Client, error) {
 
 	logger := log.WithField("releaser", GITONLY)
 
@@ -43,14 +56,25 @@ func New(config *config.GitProvider, git *gitutil.GitUtil, checkConfig bool) (*C
 		return nil, fmt.Errorf("git auth not set")
 	}
 
-	if config.SSH {
-		return nil, fmt.Errorf("git ssh not supported yet")
+	if config.SSH && config.SSHKey == "" && os.Getenv("SSH_AUTH_SOCK") == "" && checkConfig {
+		return nil, fmt.Errorf("git sshKey not set and no ssh agent found (SSH_AUTH_SOCK)")
+	}
+
+	if config.Remote == "" {
+		config.Remote = defaultRemote
+	}
+
+	key, err := signKey(config.SignKeyFile, config.SignKeyEnv, config.SignKeyPassphrase)
+	if err != nil {
+		return nil, err
 	}
 
 	return &Client{
-		config: config,
-		log:    logger,
-		git:    git,
+		config:  config,
+		log:     logger,
+		git:     git,
+		dryRun:  dryRun,
+		signKey: key,
 	}, nil
 }
 
@@ -64,6 +88,12 @@ func (g *Client) GetCompareURL(oldVersion, newVersion string) string {
 	return ""
 }
 
+// PublishRelease does nothing, releases of git are never drafts
+func (g *Client) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	g.log.Infof("Release %s is already published", releaseVersion.Next.String())
+	return false, nil
+}
+
 // CreateRelease creates release on remote
 func (g *Client) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog, _ *assets.Set) error {
 
@@ -71,7 +101,7 @@ func (g *Client) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedC
 	if g.config.TagPrefix != nil{
 		tagPrefix = *g.config.TagPrefix
 	}
-	tag := tagPrefix + releaseVersion.Next.Version.String()
+	tag := tagPrefix + releaseVersion.Next.String()
 
 	g.log.Infof("create release with version %s", tag)
 
@@ -79,26 +109,173 @@ func (g *Client) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedC
 	if err != nil {
 		return err
 	}
+	target := head.Hash()
+	if g.commit != "" {
+		target = plumbing.NewHash(g.commit)
+	}
 
-	_, err = g.git.Repository.CreateTag(tag, head.Hash(), &git.CreateTagOptions{Message: "Release " + tag, Tagger: &object.Signature{
-		Name:  g.config.Username,
-		Email: g.config.Email,
-		When:  time.Now(),
-	}})
+	message := "Release " + tag
+	if g.config.ChangelogInTag {
+		message += "\n\n" + generatedChangelog.Content
+	}
+
+	refSpecs := []gitConfig.RefSpec{"refs/tags/*:refs/tags/*"}
+	if g.branch != "" {
+		refSpecs = append(refSpecs, gitConfig.RefSpec(fmt.Sprintf("%s:refs/heads/%s", target.String(), g.branch)))
+	}
+
+	if g.dryRun != nil {
+		flag := "-a"
+		if g.signKey != nil {
+			flag = "-s"
+		}
+		dryRunTarget := target.String()
+		if g.branch != "" {
+			dryRunTarget = "HEAD"
+		}
+		g.dryRun.Record("git tag %s %s %s\n%s", flag, tag, dryRunTarget, message)
+		specs := []string{}
+		for _, refSpec := range refSpecs {
+			specs = append(specs, strings.Replace(refSpec.String(), target.String(), "HEAD", 1))
+		}
+		g.dryRun.Record("git push %s %s", g.remote(), strings.Join(specs, " "))
+		return nil
+	}
+
+	existing, err := g.tagTarget(tag)
 	if err != nil {
 		return err
 	}
+	created := false
+	switch {
+	case existing == nil:
+		_, err = g.git.Repository.CreateTag(tag, target, &git.CreateTagOptions{
+			Message: message,
+			Tagger:  g.signature(),
+			SignKey: g.signKey,
+		})
+		if err != nil {
+			return err
+		}
+		created = true
+		g.log.Infof("Created release")
+	case *existing == target:
+		g.log.Infof("Tag %s already exists on %s, will push it", tag, target.String())
+	default:
+		return fmt.Errorf("tag %s already exists on %s", tag, existing.String())
+	}
 
-	g.log.Infof("Created release")
+	if err := g.push(refSpecs); err != nil {
+		if created {
+			// a local tag without a pushed release would end the rerun with "no new version"
+			if deleteErr := g.git.Repository.DeleteTag(tag); deleteErr != nil {
+				g.log.Warnf("Could not delete tag %s: %s", tag, deleteErr.Error())
+			}
+		}
+		return err
+	}
+	return nil
+}
 
-	return g.git.Repository.Push(&git.PushOptions{
-		Auth: &http.BasicAuth{
-			Username: g.config.Username,
-			Password: g.config.Auth,
-		},
-		RefSpecs: []gitConfig.RefSpec{"refs/tags/*:refs/tags/*"},
+// push the ref specs to the remote
+func (g *Client) push(refSpecs []gitConfig.RefSpec) error {
+	auth, err := g.auth()
+	if err != nil {
+		return err
+	}
+
+	err = g.git.Repository.Push(&git.PushOptions{
+		RemoteName: g.config.Remote,
+		RemoteURL:  g.config.RemoteURL,
+		Auth:       auth,
+		RefSpecs:   refSpecs,
 	})
+	if err == git.NoErrAlreadyUpToDate {
+		return nil
+	}
+	return err
+}
 
+// tagTarget returns the commit of the local tag, nil if the tag does not exist
+func (g *Client) tagTarget(tag string) (*plumbing.Hash, error) {
+	ref, err := g.git.Repository.Tag(tag)
+	if err == git.ErrTagNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash := ref.Hash()
+	tagObject, err := g.git.Repository.TagObject(hash)
+	if err == nil {
+		hash = tagObject.Target
+	} else if err != plumbing.ErrObjectNotFound {
+		return nil, err
+	}
+	return &hash, nil
+}
+
+// CreateCommit with the release files on HEAD, the commit is pushed to the release branch with the tag
+func (g *Client) CreateCommit(releaseVersion *shared.ReleaseVersion, commit *shared.ReleaseCommit) (string, error) {
+	g.branch = releaseVersion.Branch
+
+	if g.dryRun != nil {
+		paths := []string{}
+		for _, file := range commit.Files {
+			paths = append(paths, file.Path)
+		}
+		g.dryRun.Record("git commit -m %q -- %s", commit.Message, strings.Join(paths, " "))
+		return "", nil
+	}
+
+	worktree, err := g.git.Repository.Worktree()
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range commit.Files {
+		if err := billyutil.WriteFile(worktree.Filesystem, file.Path, file.Content, 0644); err != nil {
+			return "", fmt.Errorf("could not write %s: %w", file.Path, err)
+		}
+		if _, err := worktree.Add(file.Path); err != nil {
+			return "", fmt.Errorf("could not add %s: %w", file.Path, err)
+		}
+	}
+
+	hash, err := worktree.Commit(commit.Message, &git.CommitOptions{
+		Author:  g.signature(),
+		SignKey: g.signKey,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	g.commit = hash.String()
+	g.log.Infof("Created release commit %s", g.commit)
+	return g.commit, nil
+}
+
+// SetReleaseCommit which was created by a previous run, it is tagged and pushed to the release branch
+func (g *Client) SetReleaseCommit(releaseVersion *shared.ReleaseVersion, hash string) {
+	g.branch = releaseVersion.Branch
+	g.commit = hash
+}
+
+func (g *Client) signature() *object.Signature {
+	return &object.Signature{
+		Name:  g.config.Username,
+		Email: g.config.Email,
+		When:  time.Now(),
+	}
+}
+
+// remote name or url for logs
+func (g *Client) remote() string {
+	if g.config.RemoteURL != "" {
+		return g.config.RemoteURL
+	}
+	return g.config.Remote
 }
 
 // UploadAssets uploads specified assets
@@ -0,0 +1,299 @@
+package git
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/Nightapes/go-semantic-release/internal/gitutil"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	gitConfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/ssh"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRepository(t *testing.T, remoteURL string) *gitutil.GitUtil {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+	_, err = repository.CreateRemote(&gitConfig.RemoteConfig{Name: "origin", URLs: []string{remoteURL}})
+	assert.NoError(t, err)
+	return &gitutil.GitUtil{Repository: repository, TagPrefix: "v"}
+}
+
+func writeTestKey(t *testing.T, passphrase string) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	block := &pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}
+	if passphrase != "" {
+		//nolint:staticcheck // legacy encrypted pem is still supported by ssh keys
+		block, err = x509.EncryptPEMBlock(rand.Reader, block.Type, block.Bytes, []byte(passphrase), x509.PEMCipherAES256)
+		assert.NoError(t, err)
+	}
+
+	file := filepath.Join(t.TempDir(), "id_rsa")
+	assert.NoError(t, os.WriteFile(file, pem.EncodeToMemory(block), 0600))
+	return file
+}
+
+func TestNew_SSH(t *testing.T) {
+	os.Unsetenv("SSH_AUTH_SOCK")
+
+	_, err := New(&config.GitProvider{Email: "ci@example.com", Username: "ci", SSH: true}, nil, true, nil)
+	assert.Error(t, err)
+
+	c := &config.GitProvider{Email: "ci@example.com", Username: "ci", SSH: true, SSHKey: "id_rsa"}
+	_, err = New(c, nil, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "origin", c.Remote)
+}
+
+func TestClient_auth(t *testing.T) {
+	knownHosts := filepath.Join(t.TempDir(), "known_hosts")
+	assert.NoError(t, os.WriteFile(knownHosts, []byte("example.com ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIOMqqnkVzrm0SdG6UOoqKLsabgH5C9okWi0dh2l9GKJl\n"), 0600))
+
+	client, err := New(&config.GitProvider{Username: "ci", Auth: "token"}, newTestRepository(t, "https://example.com/repo.git"), false, nil)
+	assert.NoError(t, err)
+	auth, err := client.auth()
+	assert.NoError(t, err)
+	assert.Equal(t, &http.BasicAuth{Username: "ci", Password: "token"}, auth)
+
+	client, err = New(&config.GitProvider{SSH: true, SSHKey: writeTestKey(t, "secret"), SSHKeyPassphrase: "secret", KnownHosts: knownHosts}, newTestRepository(t, "deploy@example.com:org/repo.git"), false, nil)
+	assert.NoError(t, err)
+	auth, err = client.auth()
+	assert.NoError(t, err)
+	assert.Equal(t, "deploy", auth.(*ssh.PublicKeys).User)
+	assert.NotNil(t, auth.(*ssh.PublicKeys).HostKeyCallback)
+
+	client, err = New(&config.GitProvider{SSH: true, SSHKey: writeTestKey(t, ""), InsecureIgnoreHostKey: true, RemoteURL: "ssh://example.com/org/repo.git"}, newTestRepository(t, "deploy@example.com:org/repo.git"), false, nil)
+	assert.NoError(t, err)
+	auth, err = client.auth()
+	assert.NoError(t, err)
+	assert.Equal(t, "git", auth.(*ssh.PublicKeys).User)
+
+	client, err = New(&config.GitProvider{SSH: true, SSHKey: writeTestKey(t, "secret"), SSHKeyPassphrase: "wrong", KnownHosts: knownHosts}, newTestRepository(t, "git@example.com:org/repo.git"), false, nil)
+	assert.NoError(t, err)
+	_, err = client.auth()
+	assert.Error(t, err)
+
+	client, err = New(&config.GitProvider{SSH: true, SSHKey: writeTestKey(t, ""), KnownHosts: filepath.Join(t.TempDir(), "missing")}, newTestRepository(t, "git@example.com:org/repo.git"), false, nil)
+	assert.NoError(t, err)
+	_, err = client.auth()
+	assert.Error(t, err)
+
+	client, err = New(&config.GitProvider{SSH: true, SSHKey: writeTestKey(t, ""), Remote: "upstream", InsecureIgnoreHostKey: true}, newTestRepository(t, "git@example.com:org/repo.git"), false, nil)
+	assert.NoError(t, err)
+	_, err = client.auth()
+	assert.Error(t, err)
+}
+
+func writeTestSignKey(t *testing.T, passphrase string) (string, string) {
+	entity, err := openpgp.NewEntity("ci", "", "ci@example.com", nil)
+	assert.NoError(t, err)
+
+	public := &bytes.Buffer{}
+	w, err := armor.Encode(public, openpgp.PublicKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.Serialize(w))
+	assert.NoError(t, w.Close())
+
+	if passphrase != "" {
+		assert.NoError(t, entity.PrivateKey.Encrypt([]byte(passphrase)))
+		for _, subkey := range entity.Subkeys {
+			assert.NoError(t, subkey.PrivateKey.Encrypt([]byte(passphrase)))
+		}
+	}
+	private := &bytes.Buffer{}
+	w, err = armor.Encode(private, openpgp.PrivateKeyType, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, entity.SerializePrivateWithoutSigning(w, nil))
+	assert.NoError(t, w.Close())
+
+	return private.String(), public.String()
+}
+
+func TestClient_CreateRelease_Signed(t *testing.T) {
+	remote := t.TempDir()
+	_, err := git.PlainInit(remote, true)
+	assert.NoError(t, err)
+
+	repository := newTestRepository(t, remote)
+	worktree, err := repository.Repository.Worktree()
+	assert.NoError(t, err)
+	_, err = worktree.Commit("feat: init", &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "ci", Email: "ci@example.com", When: time.Now()}})
+	assert.NoError(t, err)
+
+	privateKey, publicKey := writeTestSignKey(t, "secret")
+	os.Setenv("TEST_SIGN_KEY", privateKey)
+	defer os.Unsetenv("TEST_SIGN_KEY")
+
+	client, err := New(&config.GitProvider{Email: "ci@example.com", Username: "ci", SignKeyEnv: "TEST_SIGN_KEY", SignKeyPassphrase: "secret", ChangelogInTag: true}, repository, false, nil)
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0")}}, &shared.GeneratedChangelog{Title: "v1.0.0", Content: "# v1.0.0\n* init"}, nil)
+	assert.NoError(t, err)
+
+	pushed, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	ref, err := pushed.Tag("v1.0.0")
+	assert.NoError(t, err)
+	tag, err := pushed.TagObject(ref.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, "Release v1.0.0\n\n# v1.0.0\n* init\n", tag.Message)
+	_, err = tag.Verify(publicKey)
+	assert.NoError(t, err)
+}
+
+func TestNew_SignKey(t *testing.T) {
+	privateKey, _ := writeTestSignKey(t, "secret")
+	file := filepath.Join(t.TempDir(), "key.asc")
+	assert.NoError(t, os.WriteFile(file, []byte(privateKey), 0600))
+
+	client, err := New(&config.GitProvider{SignKeyFile: file, SignKeyPassphrase: "secret"}, nil, false, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, client.signKey)
+
+	_, err = New(&config.GitProvider{SignKeyFile: file, SignKeyPassphrase: "wrong"}, nil, false, nil)
+	assert.Error(t, err)
+
+	_, err = New(&config.GitProvider{SignKeyFile: file, SignKeyEnv: "TEST_SIGN_KEY"}, nil, false, nil)
+	assert.Error(t, err)
+
+	_, err = New(&config.GitProvider{SignKeyEnv: "TEST_SIGN_KEY_MISSING"}, nil, false, nil)
+	assert.Error(t, err)
+
+	client, err = New(&config.GitProvider{}, nil, false, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, client.signKey)
+}
+
+func TestClient_CreateCommit(t *testing.T) {
+	remote := t.TempDir()
+	_, err := git.PlainInit(remote, true)
+	assert.NoError(t, err)
+
+	repository := newTestRepository(t, remote)
+	worktree, err := repository.Repository.Worktree()
+	assert.NoError(t, err)
+	_, err = worktree.Commit("feat: init", &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "ci", Email: "ci@example.com", When: time.Now()}})
+	assert.NoError(t, err)
+
+	client, err := New(&config.GitProvider{Email: "ci@example.com", Username: "ci"}, repository, false, nil)
+	assert.NoError(t, err)
+
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0")}, Branch: "main"}
+	hash, err := client.CreateCommit(releaseVersion, &shared.ReleaseCommit{
+		Message: "chore(release): 1.0.0 [skip ci]",
+		Files:   []shared.ReleaseFile{{Path: "package.json", Content: []byte(`{"version":"1.0.0"}`)}},
+	})
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil)
+	assert.NoError(t, err)
+
+	pushed, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	branch, err := pushed.Reference("refs/heads/main", true)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, branch.Hash().String())
+	commit, err := pushed.CommitObject(branch.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, "chore(release): 1.0.0 [skip ci]", commit.Message)
+	file, err := commit.File("package.json")
+	assert.NoError(t, err)
+	content, err := file.Contents()
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version":"1.0.0"}`, content)
+
+	ref, err := pushed.Tag("v1.0.0")
+	assert.NoError(t, err)
+	tag, err := pushed.TagObject(ref.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, branch.Hash(), tag.Target)
+}
+
+func TestClient_CreateRelease_ResumeCommit(t *testing.T) {
+	remote := t.TempDir()
+	_, err := git.PlainInit(remote, true)
+	assert.NoError(t, err)
+
+	repository := newTestRepository(t, remote)
+	worktree, err := repository.Repository.Worktree()
+	assert.NoError(t, err)
+	_, err = worktree.Commit("feat: init", &git.CommitOptions{AllowEmptyCommits: true, Author: &object.Signature{Name: "ci", Email: "ci@example.com", When: time.Now()}})
+	assert.NoError(t, err)
+
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0")}, Branch: "main"}
+	client, err := New(&config.GitProvider{Email: "ci@example.com", Username: "ci", Remote: "missing"}, repository, false, nil)
+	assert.NoError(t, err)
+	hash, err := client.CreateCommit(releaseVersion, &shared.ReleaseCommit{
+		Message: "chore(release): 1.0.0",
+		Files:   []shared.ReleaseFile{{Path: "package.json", Content: []byte(`{"version":"1.0.0"}`)}},
+	})
+	assert.NoError(t, err)
+	assert.Error(t, client.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil), "push to missing remote")
+	_, err = repository.Repository.Tag("v1.0.0")
+	assert.Equal(t, git.ErrTagNotFound, err, "tag of the failed push is removed")
+
+	// the rerun skips the release commit, the branch and commit of the first run are restored
+	client, err = New(&config.GitProvider{Email: "ci@example.com", Username: "ci"}, repository, false, nil)
+	assert.NoError(t, err)
+	client.SetReleaseCommit(releaseVersion, hash)
+	assert.NoError(t, client.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil))
+
+	pushed, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	branch, err := pushed.Reference("refs/heads/main", true)
+	assert.NoError(t, err)
+	assert.Equal(t, hash, branch.Hash().String())
+	ref, err := pushed.Tag("v1.0.0")
+	assert.NoError(t, err)
+	tag, err := pushed.TagObject(ref.Hash())
+	assert.NoError(t, err)
+	assert.Equal(t, hash, tag.Target.String())
+}
+
+func TestClient_CreateRelease_ExistingTag(t *testing.T) {
+	remote := t.TempDir()
+	_, err := git.PlainInit(remote, true)
+	assert.NoError(t, err)
+
+	repository := newTestRepository(t, remote)
+	worktree, err := repository.Repository.Worktree()
+	assert.NoError(t, err)
+	signature := &object.Signature{Name: "ci", Email: "ci@example.com", When: time.Now()}
+	first, err := worktree.Commit("feat: init", &git.CommitOptions{AllowEmptyCommits: true, Author: signature})
+	assert.NoError(t, err)
+	_, err = repository.Repository.CreateTag("v1.0.0", first, &git.CreateTagOptions{Message: "Release v1.0.0", Tagger: signature})
+	assert.NoError(t, err)
+
+	client, err := New(&config.GitProvider{Email: "ci@example.com", Username: "ci"}, repository, false, nil)
+	assert.NoError(t, err)
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0")}}
+	assert.NoError(t, client.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil))
+
+	pushed, err := git.PlainOpen(remote)
+	assert.NoError(t, err)
+	_, err = pushed.Tag("v1.0.0")
+	assert.NoError(t, err)
+
+	// the tag points to another commit
+	_, err = worktree.Commit("fix: second", &git.CommitOptions{AllowEmptyCommits: true, Author: signature})
+	assert.NoError(t, err)
+	assert.Error(t, client.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil))
+}
@@ -0,0 +1,57 @@
+package git
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+)
+
+// signKey from the armored key file or environment variable, nil if tags should not be signed
+func signKey(signKeyFile, signKeyEnv, passphrase string) (*openpgp.Entity, error) {
+	var reader io.Reader
+	switch {
+	case signKeyFile != "" && signKeyEnv != "":
+		return nil, fmt.Errorf("git signKeyFile and signKeyEnv are set, use only one of them")
+	case signKeyFile != "":
+		file, err := os.Open(signKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("could not open sign key %s: %w", signKeyFile, err)
+		}
+		defer file.Close()
+		reader = file
+	case signKeyEnv != "":
+		key := os.Getenv(signKeyEnv)
+		if key == "" {
+			return nil, fmt.Errorf("sign key %s is not set in environment variables or is empty", signKeyEnv)
+		}
+		reader = strings.NewReader(key)
+	default:
+		return nil, nil
+	}
+
+	entities, err := openpgp.ReadArmoredKeyRing(reader)
+	if err != nil {
+		return nil, fmt.Errorf("could not read sign key: %w", err)
+	}
+	entity := entities[0]
+	if entity.PrivateKey == nil {
+		return nil, fmt.Errorf("sign key has no private key")
+	}
+
+	if entity.PrivateKey.Encrypted {
+		if err := entity.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+			return nil, fmt.Errorf("could not decrypt sign key: %w", err)
+		}
+	}
+	for _, subkey := range entity.Subkeys {
+		if subkey.PrivateKey != nil && subkey.PrivateKey.Encrypted {
+			if err := subkey.PrivateKey.Decrypt([]byte(passphrase)); err != nil {
+				return nil, fmt.Errorf("could not decrypt sign subkey: %w", err)
+			}
+		}
+	}
+	return entity, nil
+}
@@ -0,0 +1,252 @@
+package gitea
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// GITEA identifer for gitea and forgejo interface
+const GITEA = "gitea"
+
+// Client type struct
+type Client struct {
+	config  *config.GiteaProvider
+	client  *http.Client
+	baseURL string
+	apiURL  string
+	token   string
+	release *Release
+	log     *log.Entry
+}
+
+// New initialize a new gitea release, calls are only recorded with dryRun
+func New(config *config.GiteaProvider, checkConfig bool, dryRun *util.DryRun) (*Client, error) {
+	accessToken, err := util.GetAccessToken(fmt.Sprintf("%s_TOKEN", strings.ToUpper(GITEA)))
+	if err != nil && checkConfig {
+		return nil, err
+	}
+	config.AccessToken = accessToken
+
+	tokenHeader := util.NewAddHeaderTransport(dryRun.Transport(), "Authorization", "token "+accessToken)
+	acceptHeader := util.NewAddHeaderTransport(tokenHeader, "Accept", "application/json")
+	httpClient := &http.Client{
+		Transport: acceptHeader,
+		Timeout:   time.Second * 60,
+	}
+
+	logger := log.WithField("releaser", GITEA)
+
+	logger.Debugf("validate gitea provider config")
+
+	if config.Repo == "" && checkConfig {
+		return nil, fmt.Errorf("gitea repo is not set")
+	}
+
+	if config.User == "" && checkConfig {
+		return nil, fmt.Errorf("gitea user is not set")
+	}
+
+	if config.CustomURL == "" && checkConfig {
+		return nil, fmt.Errorf("gitea customUrl is not set")
+	}
+
+	config.CustomURL = strings.TrimRight(config.CustomURL, "/")
+	logger.Debugf("Use gitea url %s", config.CustomURL)
+
+	return &Client{
+		token:   accessToken,
+		config:  config,
+		baseURL: config.CustomURL,
+		apiURL:  config.CustomURL + "/api/v1",
+		client:  httpClient,
+		log:     logger,
+	}, nil
+}
+
+// GetCommitURL for gitea
+func (g *Client) GetCommitURL() string {
+	return fmt.Sprintf("%s/%s/%s/commit/{{hash}}", g.baseURL, g.config.User, g.config.Repo)
+}
+
+// GetCompareURL for gitea
+func (g *Client) GetCompareURL(oldVersion, newVersion string) string {
+	return fmt.Sprintf("%s/%s/%s/compare/%s...%s", g.baseURL, g.config.User, g.config.Repo, oldVersion, newVersion)
+}
+
+// PublishRelease does nothing, releases of gitea are never drafts
+func (g *Client) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	g.log.Infof("Release %s is already published", releaseVersion.Next.String())
+	return false, nil
+}
+
+// CreateRelease creates release on remote
+func (g *Client) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog, assets *assets.Set) error {
+	err := g.makeRelease(releaseVersion, generatedChangelog)
+	if err != nil {
+		return err
+	}
+	return g.uploadAssets(assets)
+}
+
+func (g *Client) makeRelease(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog) error {
+
+	tagPrefix := config.DefaultTagPrefix
+	if g.config.TagPrefix != nil {
+		tagPrefix = *g.config.TagPrefix
+	}
+	tag := tagPrefix + releaseVersion.Next.String()
+	g.log.Infof("create release with version %s", tag)
+
+	url := fmt.Sprintf("%s/repos/%s/%s/releases", g.apiURL, g.config.User, g.config.Repo)
+	g.log.Infof("Send release to %s", url)
+
+	bodyBytes, err := json.Marshal(Release{
+		TagName:         tag,
+		TargetCommitish: releaseVersion.Branch,
+		Name:            generatedChangelog.Title,
+		Body:            generatedChangelog.Content,
+		Prerelease:      releaseVersion.Next.Version.Prerelease() != "",
+	})
+	if err != nil {
+		return err
+	}
+
+	g.log.Tracef("Send release config %s", bodyBytes)
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("could not create request: %s", err.Error())
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	release := &Release{}
+	resp, err := util.Do(g.client, req, release)
+	if err != nil {
+		return fmt.Errorf("could not create release: %s", err.Error())
+	}
+
+	if resp.StatusCode == http.StatusConflict {
+		g.log.Infof("A release with tag %s already exits, will upload missing assets", tag)
+		g.release, err = g.getRelease(tag)
+		return err
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return err
+	}
+
+	g.release = release
+	g.log.Infof("Created release")
+	return nil
+}
+
+// getRelease by tag with its assets
+func (g *Client) getRelease(tag string) (*Release, error) {
+	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s/%s/releases/tags/%s", g.apiURL, g.config.User, g.config.Repo, url.PathEscape(tag)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	release := &Release{}
+	resp, err := util.Do(g.client, req, release)
+	if err != nil {
+		return nil, fmt.Errorf("could not get release %s: %s", tag, err.Error())
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+func (g *Client) uploadAssets(assets *assets.Set) error {
+	if g.release == nil {
+		return nil
+	}
+
+	uploaded := map[string]bool{}
+	for _, attachment := range g.release.Assets {
+		uploaded[attachment.Name] = true
+	}
+
+	for _, asset := range assets.All() {
+		if uploaded[asset.GetName()] {
+			g.log.Infof("Asset %s already exists, skip upload", asset.GetName())
+			continue
+		}
+
+		path, err := asset.GetPath()
+		if err != nil {
+			return err
+		}
+
+		attachment, err := g.uploadFile(asset.GetName(), path)
+		if err != nil {
+			return fmt.Errorf("could not upload asset %s: %s", asset.GetName(), err.Error())
+		}
+
+		g.log.Infof("Uploaded file %s to gitea can be downloaded under %s", asset.GetName(), attachment.BrowserDownloadURL)
+	}
+	return nil
+}
+
+func (g *Client) uploadFile(fileName, path string) (*Attachment, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	b := &bytes.Buffer{}
+	w := multipart.NewWriter(b)
+
+	fw, err := w.CreateFormFile("attachment", fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = io.Copy(fw, file)
+	if err != nil {
+		return nil, err
+	}
+	w.Close()
+
+	uploadURL := fmt.Sprintf("%s/repos/%s/%s/releases/%d/assets?name=%s", g.apiURL, g.config.User, g.config.Repo, g.release.ID, url.QueryEscape(fileName))
+
+	req, err := http.NewRequest("POST", uploadURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Body = ioutil.NopCloser(b)
+	req.ContentLength = int64(b.Len())
+	req.Header.Set("Content-Type", w.FormDataContentType())
+
+	attachment := &Attachment{}
+	resp, err := util.Do(g.client, req, attachment)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = util.IsValidResult(resp); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
@@ -0,0 +1,201 @@
+package gitea
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/Nightapes/go-semantic-release/pkg/config"
+)
+
+func TestGetCommitURL(t *testing.T) {
+	os.Setenv("GITEA_TOKEN", "XXX")
+	defer os.Unsetenv("GITEA_TOKEN")
+	client, err := New(&config.GiteaProvider{
+		CustomURL: "https://gitea.example.com/",
+		User:      "foo",
+		Repo:      "bar",
+	}, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/foo/bar/commit/{{hash}}", client.GetCommitURL())
+}
+
+func TestGetCompareURL(t *testing.T) {
+	os.Setenv("GITEA_TOKEN", "XXX")
+	defer os.Unsetenv("GITEA_TOKEN")
+	client, err := New(&config.GiteaProvider{
+		CustomURL: "https://gitea.example.com",
+		User:      "foo",
+		Repo:      "bar",
+	}, true, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "https://gitea.example.com/foo/bar/compare/v1.0.0...v1.0.1", client.GetCompareURL("v1.0.0", "v1.0.1"))
+}
+
+func TestValidateConfig(t *testing.T) {
+	os.Setenv("GITEA_TOKEN", "XXX")
+	defer os.Unsetenv("GITEA_TOKEN")
+
+	for _, c := range []config.GiteaProvider{
+		{User: "foo", CustomURL: "https://gitea.example.com"},
+		{Repo: "bar", CustomURL: "https://gitea.example.com"},
+		{User: "foo", Repo: "bar"},
+	} {
+		_, err := New(&c, true, nil)
+		assert.Error(t, err)
+	}
+}
+
+func TestCreateRelease(t *testing.T) {
+
+	lastVersion, _ := semver.NewVersion("1.0.0")
+	newVersion, _ := semver.NewVersion("2.0.0-rc.0")
+
+	file, err := ioutil.TempFile("", "prefix")
+	assert.NoError(t, err)
+	defer os.Remove(file.Name())
+	_, err = file.WriteString("testFile")
+	assert.NoError(t, err)
+
+	testReleases := []struct {
+		responseCode []int
+		requestBody  string
+		valid        bool
+		calls        int
+	}{
+		{
+			responseCode: []int{201, 201},
+			requestBody:  `{"tag_name":"v2.0.0-rc.0","target_commitish":"master","name":"title","body":"content","draft":false,"prerelease":true}`,
+			valid:        true,
+			calls:        2,
+		},
+		{
+			responseCode: []int{500},
+			valid:        false,
+			calls:        1,
+		},
+		{
+			responseCode: []int{201, 400},
+			valid:        false,
+			calls:        2,
+		},
+	}
+
+	for _, testObject := range testReleases {
+		calls := 0
+		testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+
+			log.Infof("Got call from %s %s", req.Method, req.URL.String())
+
+			assert.Equal(t, "token aToken", req.Header.Get("Authorization"))
+			assert.Equal(t, "POST", req.Method)
+
+			bodyBytes, err := ioutil.ReadAll(req.Body)
+			assert.NoError(t, err)
+
+			switch calls {
+			case 0:
+				assert.Equal(t, "/api/v1/repos/foo/bar/releases", req.URL.String())
+				if testObject.requestBody != "" {
+					assert.Equal(t, testObject.requestBody, string(bodyBytes))
+				}
+			case 1:
+				assert.Equal(t, "/api/v1/repos/foo/bar/releases/42/assets?name="+filepath.Base(file.Name()), req.URL.String())
+				assert.Contains(t, string(bodyBytes), "testFile")
+			}
+
+			rw.Header().Set("Content-Type", "application/json")
+			rw.WriteHeader(testObject.responseCode[calls])
+			if _, err := rw.Write([]byte(fmt.Sprintf(`{"id": 42, "browser_download_url": "%s/attachments/1"}`, req.Host))); err != nil {
+				log.Info(err)
+			}
+			calls++
+		}))
+
+		os.Setenv("GITEA_TOKEN", "aToken")
+		client, err := New(&config.GiteaProvider{
+			CustomURL: testServer.URL,
+			User:      "foo",
+			Repo:      "bar",
+		}, true, nil)
+		assert.NoError(t, err)
+
+		set := assets.New(os.TempDir(), "")
+		err = set.Add(config.Asset{Path: filepath.Base(file.Name())})
+		assert.NoError(t, err)
+
+		err = client.CreateRelease(&shared.ReleaseVersion{
+			Last: shared.ReleaseVersionEntry{
+				Version: lastVersion,
+				Commit:  "foo",
+			},
+			Next: shared.ReleaseVersionEntry{
+				Version: newVersion,
+				Commit:  "bar",
+			},
+			Branch: "master",
+		}, &shared.GeneratedChangelog{
+			Title:   "title",
+			Content: "content",
+		}, set)
+		if err != nil {
+			t.Log(err)
+		}
+		assert.Equal(t, testObject.valid, err == nil)
+		assert.Equal(t, testObject.calls, calls)
+
+		testServer.Close()
+		os.Unsetenv("GITEA_TOKEN")
+	}
+}
+
+func TestCreateRelease_Resume(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	set := assets.New(dir, "")
+	assert.NoError(t, set.Add(config.Asset{Path: "a.txt"}, config.Asset{Path: "b.txt"}))
+
+	calls := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls = append(calls, req.Method+" "+req.URL.RequestURI())
+		rw.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == "POST" && req.URL.Path == "/api/v1/repos/foo/bar/releases":
+			rw.WriteHeader(http.StatusConflict)
+		case req.Method == "GET":
+			fmt.Fprint(rw, `{"id":42,"tag_name":"v2.0.0","assets":[{"id":1,"name":"a.txt"}]}`)
+		default:
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"id":2,"name":"b.txt"}`)
+		}
+	}))
+	defer testServer.Close()
+
+	os.Setenv("GITEA_TOKEN", "aToken")
+	defer os.Unsetenv("GITEA_TOKEN")
+	client, err := New(&config.GiteaProvider{CustomURL: testServer.URL, User: "foo", Repo: "bar"}, true, nil)
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(&shared.ReleaseVersion{
+		Next:   shared.ReleaseVersionEntry{Version: semver.MustParse("2.0.0")},
+		Branch: "master",
+	}, &shared.GeneratedChangelog{Title: "title", Content: "content"}, set)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /api/v1/repos/foo/bar/releases",
+		"GET /api/v1/repos/foo/bar/releases/tags/v2.0.0",
+		"POST /api/v1/repos/foo/bar/releases/42/assets?name=b.txt",
+	}, calls)
+}
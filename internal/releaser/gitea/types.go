@@ -0,0 +1,20 @@
+package gitea
+
+// Release struct
+type Release struct {
+	ID              int64        `json:"id,omitempty"`
+	TagName         string       `json:"tag_name"`
+	TargetCommitish string       `json:"target_commitish"`
+	Name            string       `json:"name"`
+	Body            string       `json:"body,omitempty"`
+	Draft           bool         `json:"draft"`
+	Prerelease      bool         `json:"prerelease"`
+	Assets          []Attachment `json:"assets,omitempty"`
+}
+
+// Attachment struct
+type Attachment struct {
+	ID                 int64  `json:"id"`
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
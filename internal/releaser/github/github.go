@@ -12,25 +12,40 @@ import (
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
 
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/google/go-github/v25/github"
 	log "github.com/sirupsen/logrus"
+	"golang.org/x/oauth2"
 )
 
 // GITHUB identifer for github interface
 const GITHUB = "github"
 
+const (
+	// AlreadyExistsSkip keeps an existing release and uploads only missing assets, default
+	AlreadyExistsSkip = "skip"
+	// AlreadyExistsFail fails if the release exists
+	AlreadyExistsFail = "fail"
+	// AlreadyExistsUpdate updates title and body of the existing release, assets are replaced by name
+	AlreadyExistsUpdate = "update"
+	// AlreadyExistsRecreate deletes the existing release and creates it again
+	AlreadyExistsRecreate = "recreate"
+)
+
 // Client type struct
 type Client struct {
 	config  *config.GitHubProvider
 	client  *github.Client
 	context context.Context
 	release *github.RepositoryRelease
+	commit  string
 	baseURL string
 	log     *log.Entry
+	dryRun  *util.DryRun
 }
 
-// New initialize a new GitHubRelease
-func New(c *config.GitHubProvider, checkConfig bool) (*Client, error) {
+// New initialize a new GitHubRelease, calls are only recorded with dryRun
+func New(c *config.GitHubProvider, checkConfig bool, dryRun *util.DryRun) (*Client, error) {
 
 	token, err := util.GetAccessToken("GITHUB_TOKEN")
 	if err != nil && checkConfig {
@@ -38,6 +53,9 @@ func New(c *config.GitHubProvider, checkConfig bool) (*Client, error) {
 	}
 	c.AccessToken = token
 	ctx := context.Background()
+	if dryRun != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, &http.Client{Transport: dryRun})
+	}
 	httpClient := util.CreateBearerHTTPClient(ctx, c.AccessToken)
 
 	var client *github.Client
@@ -51,6 +69,14 @@ func New(c *config.GitHubProvider, checkConfig bool) (*Client, error) {
 		return nil, fmt.Errorf("github user is not set")
 	}
 
+	switch c.AlreadyExists {
+	case "":
+		c.AlreadyExists = AlreadyExistsSkip
+	case AlreadyExistsSkip, AlreadyExistsFail, AlreadyExistsUpdate, AlreadyExistsRecreate:
+	default:
+		return nil, fmt.Errorf("github alreadyExists %s is not supported, use %s, %s, %s or %s", c.AlreadyExists, AlreadyExistsSkip, AlreadyExistsFail, AlreadyExistsUpdate, AlreadyExistsRecreate)
+	}
+
 	if c.CustomURL == "" {
 		client = github.NewClient(httpClient)
 	} else {
@@ -68,6 +94,7 @@ func New(c *config.GitHubProvider, checkConfig bool) (*Client, error) {
 		context: ctx,
 		baseURL: baseURL,
 		log:     log.WithField("releaser", GITHUB),
+		dryRun:  dryRun,
 	}, nil
 }
 
@@ -97,22 +124,46 @@ func (g *Client) makeRelease(releaseVersion *shared.ReleaseVersion, generatedCha
 	if g.config.TagPrefix != nil {
 		tagPrefix = *g.config.TagPrefix
 	}
-	tag := tagPrefix + releaseVersion.Next.Version.String()
+	tag := tagPrefix + releaseVersion.Next.String()
 	g.log.Debugf("create release with version %s", tag)
 
 	prerelease := releaseVersion.Next.Version.Prerelease() != ""
 
-	release, _, err := g.client.Repositories.CreateRelease(g.context, g.config.User, g.config.Repo, &github.RepositoryRelease{
+	target := releaseVersion.Branch
+	if g.commit != "" {
+		target = g.commit
+	} else if g.config.Draft && releaseVersion.Next.Commit != "" {
+		// the tag of a draft is created on publish, the branch could have moved until then
+		target = releaseVersion.Next.Commit
+	}
+
+	newRelease := &github.RepositoryRelease{
 		TagName:         &tag,
-		TargetCommitish: &releaseVersion.Branch,
+		TargetCommitish: &target,
 		Name:            &generatedChangelog.Title,
 		Body:            &generatedChangelog.Content,
 		Prerelease:      &prerelease,
-	})
+		Draft:           &g.config.Draft,
+	}
+
+	if g.config.Draft && g.dryRun != nil {
+		// the recorded list of releases is no json array
+		g.dryRun.Record("find draft release %s", tag)
+	} else if g.config.Draft {
+		// a draft has no tag, github would create a second draft for the same tag
+		draft, err := g.findRelease(tag)
+		if err != nil {
+			return err
+		}
+		if draft != nil {
+			return g.existingRelease(tag, newRelease, draft)
+		}
+	}
+
+	release, _, err := g.client.Repositories.CreateRelease(g.context, g.config.User, g.config.Repo, newRelease)
 	if err != nil {
 		if strings.Contains(err.Error(), "already_exists") {
-			g.log.Infof("A release with tag %s already exits, will not perform a release or update", tag)
-			return nil
+			return g.existingRelease(tag, newRelease, nil)
 		}
 		return fmt.Errorf("could not create release: %s", err.Error())
 	}
@@ -123,10 +174,207 @@ func (g *Client) makeRelease(releaseVersion *shared.ReleaseVersion, generatedCha
 
 }
 
+// PublishRelease publishes the draft release of the version
+func (g *Client) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	tagPrefix := config.DefaultTagPrefix
+	if g.config.TagPrefix != nil {
+		tagPrefix = *g.config.TagPrefix
+	}
+	tag := tagPrefix + releaseVersion.Next.String()
+
+	release, err := g.findRelease(tag)
+	if err != nil {
+		return false, err
+	}
+	if release == nil {
+		return false, fmt.Errorf("no release found for tag %s", tag)
+	}
+
+	if !release.GetDraft() {
+		g.log.Infof("Release %s is already published", tag)
+		return false, nil
+	}
+
+	_, _, err = g.client.Repositories.EditRelease(g.context, g.config.User, g.config.Repo, release.GetID(), &github.RepositoryRelease{
+		Draft: github.Bool(false),
+	})
+	if err != nil {
+		return false, fmt.Errorf("could not publish release %s: %s", tag, err.Error())
+	}
+	g.log.Infof("Published release %s", tag)
+
+	// drafts target the released commit, older drafts target the branch
+	if target := release.GetTargetCommitish(); plumbing.IsHash(target) {
+		releaseVersion.Next.Commit = target
+	}
+	return true, nil
+}
+
+// findRelease by tag, drafts are only found in the list of releases, nil if there is no release
+func (g *Client) findRelease(tag string) (*github.RepositoryRelease, error) {
+	opt := &github.ListOptions{PerPage: 100}
+	for {
+		releases, resp, err := g.client.Repositories.ListReleases(g.context, g.config.User, g.config.Repo, opt)
+		if err != nil {
+			return nil, fmt.Errorf("could not list releases: %s", err.Error())
+		}
+		for _, release := range releases {
+			if release.GetTagName() == tag {
+				return release, nil
+			}
+		}
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// existingRelease handles a release which exists already for the tag, depending on the alreadyExists mode, the release is fetched if nil
+func (g *Client) existingRelease(tag string, newRelease, release *github.RepositoryRelease) error {
+	if g.config.AlreadyExists == AlreadyExistsFail {
+		return fmt.Errorf("a release with tag %s already exists", tag)
+	}
+
+	if release == nil {
+		var err error
+		release, _, err = g.client.Repositories.GetReleaseByTag(g.context, g.config.User, g.config.Repo, tag)
+		if err != nil {
+			return fmt.Errorf("could not get release %s: %s", tag, err.Error())
+		}
+	}
+
+	switch g.config.AlreadyExists {
+	case AlreadyExistsUpdate:
+		g.log.Infof("A release with tag %s already exits, will update it", tag)
+		updated, _, err := g.client.Repositories.EditRelease(g.context, g.config.User, g.config.Repo, release.GetID(), &github.RepositoryRelease{
+			Name:       newRelease.Name,
+			Body:       newRelease.Body,
+			Prerelease: newRelease.Prerelease,
+		})
+		if err != nil {
+			return fmt.Errorf("could not update release %s: %s", tag, err.Error())
+		}
+		updated.Assets = release.Assets
+		g.release = updated
+	case AlreadyExistsRecreate:
+		g.log.Infof("A release with tag %s already exits, will recreate it", tag)
+		if _, err := g.client.Repositories.DeleteRelease(g.context, g.config.User, g.config.Repo, release.GetID()); err != nil {
+			return fmt.Errorf("could not delete release %s: %s", tag, err.Error())
+		}
+		created, _, err := g.client.Repositories.CreateRelease(g.context, g.config.User, g.config.Repo, newRelease)
+		if err != nil {
+			return fmt.Errorf("could not create release: %s", err.Error())
+		}
+		g.release = created
+	default:
+		g.log.Infof("A release with tag %s already exits, will upload missing assets", tag)
+		g.release = release
+	}
+	return nil
+}
+
+// CreateCommit with the release files on top of the released commit and move the branch to it
+func (g *Client) CreateCommit(releaseVersion *shared.ReleaseVersion, commit *shared.ReleaseCommit) (string, error) {
+	parent := releaseVersion.Next.Commit
+	if parent == "" {
+		ref, _, err := g.client.Git.GetRef(g.context, g.config.User, g.config.Repo, "heads/"+releaseVersion.Branch)
+		if err != nil {
+			return "", fmt.Errorf("could not get branch %s: %s", releaseVersion.Branch, err.Error())
+		}
+		parent = ref.GetObject().GetSHA()
+	}
+
+	parentCommit, _, err := g.client.Git.GetCommit(g.context, g.config.User, g.config.Repo, parent)
+	if err != nil {
+		return "", fmt.Errorf("could not get commit %s: %s", parent, err.Error())
+	}
+
+	entries := []github.TreeEntry{}
+	for _, file := range commit.Files {
+		entries = append(entries, github.TreeEntry{
+			Path:    github.String(file.Path),
+			Mode:    github.String("100644"),
+			Type:    github.String("blob"),
+			Content: github.String(string(file.Content)),
+		})
+	}
+
+	tree, _, err := g.client.Git.CreateTree(g.context, g.config.User, g.config.Repo, parentCommit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return "", fmt.Errorf("could not create tree: %s", err.Error())
+	}
+
+	created, _, err := g.client.Git.CreateCommit(g.context, g.config.User, g.config.Repo, &github.Commit{
+		Message: &commit.Message,
+		Tree:    tree,
+		Parents: []github.Commit{{SHA: &parent}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not create commit: %s", err.Error())
+	}
+
+	_, _, err = g.client.Git.UpdateRef(g.context, g.config.User, g.config.Repo, &github.Reference{
+		Ref:    github.String("refs/heads/" + releaseVersion.Branch),
+		Object: &github.GitObject{SHA: created.SHA},
+	}, false)
+	if err != nil {
+		return "", fmt.Errorf("could not push commit to %s: %s", releaseVersion.Branch, err.Error())
+	}
+
+	g.commit = created.GetSHA()
+	g.log.Infof("Created release commit %s", g.commit)
+	return g.commit, nil
+}
+
+// SetReleaseCommit which was created by a previous run, the release is created on it
+func (g *Client) SetReleaseCommit(releaseVersion *shared.ReleaseVersion, hash string) {
+	g.commit = hash
+}
+
+// CreateComments on the referenced issues and pull requests, merge request references are ignored
+func (g *Client) CreateComments(releaseVersion *shared.ReleaseVersion, comments *shared.ReleaseComments) error {
+	for _, reference := range comments.References {
+		if reference.MergeRequest {
+			continue
+		}
+
+		_, _, err := g.client.Issues.CreateComment(g.context, g.config.User, g.config.Repo, reference.Number, &github.IssueComment{
+			Body: &comments.Message,
+		})
+		if err != nil {
+			return fmt.Errorf("could not comment on #%d: %s", reference.Number, err.Error())
+		}
+
+		if comments.Label != "" {
+			_, _, err = g.client.Issues.AddLabelsToIssue(g.context, g.config.User, g.config.Repo, reference.Number, []string{comments.Label})
+			if err != nil {
+				return fmt.Errorf("could not add label %s to #%d: %s", comments.Label, reference.Number, err.Error())
+			}
+		}
+		g.log.Infof("Commented release %s on #%d", releaseVersion.Next.String(), reference.Number)
+	}
+	return nil
+}
+
 // UploadAssets uploads specified assets
 func (g *Client) uploadAssets(assets *assets.Set) error {
 	if g.release != nil {
+		uploaded := map[string]int64{}
+		for _, asset := range g.release.Assets {
+			uploaded[asset.GetName()] = asset.GetID()
+		}
 		for _, asset := range assets.All() {
+			if id, ok := uploaded[asset.GetName()]; ok {
+				if g.config.AlreadyExists != AlreadyExistsUpdate {
+					g.log.Infof("Asset %s is already uploaded", asset.GetName())
+					continue
+				}
+				g.log.Infof("Replace asset %s", asset.GetName())
+				if _, err := g.client.Repositories.DeleteReleaseAsset(g.context, g.config.User, g.config.Repo, id); err != nil {
+					return fmt.Errorf("could not delete asset %s: %s", asset.GetName(), err.Error())
+				}
+			}
 			path, err := asset.GetPath()
 			if err != nil {
 				return err
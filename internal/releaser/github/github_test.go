@@ -2,15 +2,20 @@ package github
 
 import (
 	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	log "github.com/sirupsen/logrus"
 
 	"github.com/Masterminds/semver"
 
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
 	"github.com/stretchr/testify/assert"
@@ -122,7 +127,7 @@ func TestNew(t *testing.T) {
 			os.Setenv("GITHUB_TOKEN", "XXX")
 		}
 
-		_, err := New(&testOject.config, true)
+		_, err := New(&testOject.config, true, nil)
 		assert.Equal(t, testOject.valid, err == nil)
 
 		os.Unsetenv("GITHUB_TOKEN")
@@ -133,7 +138,7 @@ func TestNew(t *testing.T) {
 func TestGetCommitURL(t *testing.T) {
 	os.Setenv("GITHUB_TOKEN", "XX")
 	for _, testOject := range testNewClient {
-		client, _ := New(&testOject.config, false)
+		client, _ := New(&testOject.config, false, nil)
 		actualURL := client.GetCommitURL()
 		if testOject.config.CustomURL != "" {
 			expectedURL := fmt.Sprintf("%s/api/v3/%s/%s/commit/{{hash}}", testOject.config.CustomURL, testOject.config.User, testOject.config.Repo)
@@ -151,7 +156,7 @@ func TestGetCommitURL(t *testing.T) {
 func TestGetCompareURL(t *testing.T) {
 	os.Setenv("GITHUB_TOKEN", "XX")
 	for _, testOject := range testNewClient {
-		client, _ := New(&testOject.config, false)
+		client, _ := New(&testOject.config, false, nil)
 		actualURL := client.GetCompareURL("1", "2")
 		if testOject.config.CustomURL != "" {
 			expectedURL := fmt.Sprintf("%s/api/v3/%s/%s/compare/%s...%s", testOject.config.CustomURL, testOject.config.User, testOject.config.Repo, "1", "2")
@@ -173,7 +178,7 @@ func TestCreateRelease(t *testing.T) {
 		if testObejct.valid {
 			server := initHTTPServer(testObejct.requestResponseCode, testObejct.requestResponseBody)
 			testObejct.config.CustomURL = server.URL
-			client, _ := New(&testObejct.config, false)
+			client, _ := New(&testObejct.config, false, nil)
 
 			err := client.makeRelease(testObejct.releaseVersion, testObejct.generatedChangelog)
 			if err != nil {
@@ -185,7 +190,7 @@ func TestCreateRelease(t *testing.T) {
 
 		} else {
 			testObejct.config.CustomURL = "http://foo"
-			client, _ := New(&testObejct.config, false)
+			client, _ := New(&testObejct.config, false, nil)
 
 			err := client.makeRelease(testObejct.releaseVersion, testObejct.generatedChangelog)
 			if err != nil {
@@ -197,3 +202,279 @@ func TestCreateRelease(t *testing.T) {
 	os.Unsetenv("GITHUB_TOKEN")
 
 }
+
+func TestCreateCommit(t *testing.T) {
+	calls := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		calls = append(calls, fmt.Sprintf("%s %s %s", req.Method, req.URL.Path, strings.TrimSpace(string(body))))
+		rw.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == "GET" && req.URL.Path == "/api/v3/repos/bar/foo/git/commits/abc":
+			fmt.Fprint(rw, `{"sha":"abc","tree":{"sha":"tree1"}}`)
+		case req.URL.Path == "/api/v3/repos/bar/foo/git/trees":
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"sha":"tree2"}`)
+		case req.URL.Path == "/api/v3/repos/bar/foo/git/commits":
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"sha":"def"}`)
+		case req.URL.Path == "/api/v3/repos/bar/foo/git/refs/heads/master":
+			fmt.Fprint(rw, `{"ref":"refs/heads/master","object":{"sha":"def"}}`)
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", CustomURL: server.URL}, false, nil)
+	assert.NoError(t, err)
+
+	hash, err := client.CreateCommit(&shared.ReleaseVersion{
+		Next:   shared.ReleaseVersionEntry{Version: newVersion, Commit: "abc"},
+		Branch: "master",
+	}, &shared.ReleaseCommit{
+		Message: "chore(release): 2.0.0 [skip ci]",
+		Files:   []shared.ReleaseFile{{Path: "package.json", Content: []byte(`{"version":"2.0.0"}`)}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "def", hash)
+	assert.Equal(t, "def", client.commit)
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/bar/foo/git/commits/abc ",
+		`POST /api/v3/repos/bar/foo/git/trees {"base_tree":"tree1","tree":[{"path":"package.json","mode":"100644","type":"blob","content":"{\"version\":\"2.0.0\"}"}]}`,
+		`POST /api/v3/repos/bar/foo/git/commits {"message":"chore(release): 2.0.0 [skip ci]","tree":"tree2","parents":["abc"]}`,
+		`PATCH /api/v3/repos/bar/foo/git/refs/heads/master {"sha":"def","force":false}`,
+	}, calls)
+}
+
+func TestCreateRelease_AlreadyExists(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	set := assets.New(dir, "")
+	assert.NoError(t, set.Add(config.Asset{Name: "a.txt"}, config.Asset{Name: "b.txt"}))
+
+	testConfigs := []struct {
+		alreadyExists string
+		calls         []string
+		valid         bool
+	}{
+		{
+			alreadyExists: "",
+			calls: []string{
+				"POST /api/v3/repos/bar/foo/releases",
+				"GET /api/v3/repos/bar/foo/releases/tags/v2.0.0",
+				"POST /api/uploads/repos/bar/foo/releases/1/assets?name=b.txt",
+			},
+			valid: true,
+		},
+		{
+			alreadyExists: AlreadyExistsFail,
+			calls: []string{
+				"POST /api/v3/repos/bar/foo/releases",
+			},
+			valid: false,
+		},
+		{
+			alreadyExists: AlreadyExistsUpdate,
+			calls: []string{
+				"POST /api/v3/repos/bar/foo/releases",
+				"GET /api/v3/repos/bar/foo/releases/tags/v2.0.0",
+				"PATCH /api/v3/repos/bar/foo/releases/1",
+				"DELETE /api/v3/repos/bar/foo/releases/assets/7",
+				"POST /api/uploads/repos/bar/foo/releases/1/assets?name=a.txt",
+				"POST /api/uploads/repos/bar/foo/releases/1/assets?name=b.txt",
+			},
+			valid: true,
+		},
+		{
+			alreadyExists: AlreadyExistsRecreate,
+			calls: []string{
+				"POST /api/v3/repos/bar/foo/releases",
+				"GET /api/v3/repos/bar/foo/releases/tags/v2.0.0",
+				"DELETE /api/v3/repos/bar/foo/releases/1",
+				"POST /api/v3/repos/bar/foo/releases",
+				"POST /api/uploads/repos/bar/foo/releases/2/assets?name=a.txt",
+				"POST /api/uploads/repos/bar/foo/releases/2/assets?name=b.txt",
+			},
+			valid: true,
+		},
+	}
+
+	for _, testConfig := range testConfigs {
+		calls := []string{}
+		deleted := false
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			calls = append(calls, req.Method+" "+req.URL.RequestURI())
+			rw.Header().Set("Content-Type", "application/json")
+			switch {
+			case req.Method == "POST" && req.URL.Path == "/api/v3/repos/bar/foo/releases" && !deleted:
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				fmt.Fprint(rw, `{"message":"Validation Failed","errors":[{"resource":"Release","code":"already_exists","field":"tag_name"}]}`)
+			case req.Method == "POST" && req.URL.Path == "/api/v3/repos/bar/foo/releases":
+				rw.WriteHeader(http.StatusCreated)
+				fmt.Fprint(rw, `{"id":2,"tag_name":"v2.0.0"}`)
+			case req.URL.Path == "/api/v3/repos/bar/foo/releases/tags/v2.0.0":
+				fmt.Fprint(rw, `{"id":1,"tag_name":"v2.0.0","assets":[{"id":7,"name":"a.txt"}]}`)
+			case req.Method == "PATCH":
+				fmt.Fprint(rw, `{"id":1,"tag_name":"v2.0.0"}`)
+			case req.Method == "DELETE":
+				deleted = true
+				rw.WriteHeader(http.StatusNoContent)
+			case strings.HasPrefix(req.URL.Path, "/api/uploads/"):
+				rw.WriteHeader(http.StatusCreated)
+				fmt.Fprintf(rw, `{"id":8,"name":"%s"}`, req.URL.Query().Get("name"))
+			default:
+				rw.WriteHeader(http.StatusNotFound)
+			}
+		}))
+
+		client, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", CustomURL: server.URL, AlreadyExists: testConfig.alreadyExists}, false, nil)
+		assert.NoError(t, err)
+
+		err = client.CreateRelease(testReleases[0].releaseVersion, testReleases[0].generatedChangelog, set)
+		assert.Equal(t, testConfig.valid, err == nil, testConfig.alreadyExists)
+		assert.Equal(t, testConfig.calls, calls, testConfig.alreadyExists)
+
+		server.Close()
+	}
+}
+
+func TestCreateRelease_Draft(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	set := assets.New(dir, "")
+	assert.NoError(t, set.Add(config.Asset{Name: "a.txt"}, config.Asset{Name: "b.txt"}))
+
+	calls := []string{}
+	releases := `[]`
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		call := req.Method + " " + req.URL.RequestURI()
+		if req.Method == "POST" && req.URL.Path == "/api/v3/repos/bar/foo/releases" {
+			call += " " + strings.TrimSpace(string(body))
+		}
+		calls = append(calls, call)
+		rw.Header().Set("Content-Type", "application/json")
+		switch {
+		case req.Method == "GET":
+			fmt.Fprint(rw, releases)
+		case req.URL.Path == "/api/v3/repos/bar/foo/releases":
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"id":1,"tag_name":"v2.0.0","draft":true}`)
+		default:
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(rw, `{"id":8,"name":"%s"}`, req.URL.Query().Get("name"))
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", CustomURL: server.URL, Draft: true}, false, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.CreateRelease(testReleases[0].releaseVersion, testReleases[0].generatedChangelog, set))
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/bar/foo/releases?per_page=100",
+		`POST /api/v3/repos/bar/foo/releases {"tag_name":"v2.0.0","target_commitish":"bar","name":"title","body":"content","draft":true,"prerelease":false}`,
+		"POST /api/uploads/repos/bar/foo/releases/1/assets?name=a.txt",
+		"POST /api/uploads/repos/bar/foo/releases/1/assets?name=b.txt",
+	}, calls)
+
+	// the rerun finds the draft and only uploads the missing asset
+	calls = []string{}
+	releases = `[{"id":1,"tag_name":"v2.0.0","draft":true,"assets":[{"id":7,"name":"a.txt"}]}]`
+	client, err = New(&config.GitHubProvider{Repo: "foo", User: "bar", CustomURL: server.URL, Draft: true}, false, nil)
+	assert.NoError(t, err)
+	assert.NoError(t, client.CreateRelease(testReleases[0].releaseVersion, testReleases[0].generatedChangelog, set))
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/bar/foo/releases?per_page=100",
+		"POST /api/uploads/repos/bar/foo/releases/1/assets?name=b.txt",
+	}, calls)
+}
+
+func TestCreateRelease_DraftDryRun(t *testing.T) {
+	dryRun := util.NewDryRun()
+	client, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", Draft: true}, false, dryRun)
+	assert.NoError(t, err)
+
+	assert.NoError(t, client.CreateRelease(testReleases[0].releaseVersion, testReleases[0].generatedChangelog, assets.New(t.TempDir(), "")))
+	assert.Equal(t, []string{
+		"find draft release v2.0.0",
+		"POST https://api.github.com/repos/bar/foo/releases\n" + `{"tag_name":"v2.0.0","target_commitish":"bar","name":"title","body":"content","draft":true,"prerelease":false}`,
+	}, dryRun.Calls())
+}
+
+func TestNew_AlreadyExists(t *testing.T) {
+	_, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", AlreadyExists: "ignore"}, false, nil)
+	assert.Error(t, err)
+}
+
+func TestPublishRelease(t *testing.T) {
+	calls := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		calls = append(calls, strings.TrimSpace(req.Method+" "+req.URL.RequestURI()+" "+strings.TrimSpace(string(body))))
+		rw.Header().Set("Content-Type", "application/json")
+		switch req.Method {
+		case "GET":
+			fmt.Fprint(rw, `[{"id":1,"tag_name":"v1.0.0","draft":false},{"id":2,"tag_name":"v2.0.0","draft":true,"target_commitish":"0123456789abcdef0123456789abcdef01234567"}]`)
+		case "PATCH":
+			fmt.Fprint(rw, `{"id":2,"tag_name":"v2.0.0","draft":false}`)
+		}
+	}))
+	defer server.Close()
+
+	client, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", CustomURL: server.URL}, false, nil)
+	assert.NoError(t, err)
+
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: newVersion}}
+	published, err := client.PublishRelease(releaseVersion)
+	assert.NoError(t, err)
+	assert.True(t, published)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", releaseVersion.Next.Commit)
+
+	published, err = client.PublishRelease(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: lastVersion}})
+	assert.NoError(t, err)
+	assert.False(t, published)
+
+	_, err = client.PublishRelease(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("3.0.0")}})
+	assert.Error(t, err)
+	assert.Equal(t, []string{
+		"GET /api/v3/repos/bar/foo/releases?per_page=100",
+		`PATCH /api/v3/repos/bar/foo/releases/2 {"draft":false}`,
+		"GET /api/v3/repos/bar/foo/releases?per_page=100",
+		"GET /api/v3/repos/bar/foo/releases?per_page=100",
+	}, calls)
+}
+
+func TestCreateComments(t *testing.T) {
+	calls := []string{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := ioutil.ReadAll(req.Body)
+		calls = append(calls, req.Method+" "+req.URL.Path+" "+strings.TrimSpace(string(body)))
+		rw.Header().Set("Content-Type", "application/json")
+		if strings.HasSuffix(req.URL.Path, "/comments") {
+			rw.WriteHeader(http.StatusCreated)
+			fmt.Fprint(rw, `{"id":1}`)
+			return
+		}
+		fmt.Fprint(rw, `[]`)
+	}))
+	defer server.Close()
+
+	client, err := New(&config.GitHubProvider{Repo: "foo", User: "bar", CustomURL: server.URL}, false, nil)
+	assert.NoError(t, err)
+
+	err = client.CreateComments(testReleases[0].releaseVersion, &shared.ReleaseComments{
+		Message:    "Released in v2.0.0",
+		Label:      "released",
+		References: []shared.Reference{{Number: 12}, {Number: 7, MergeRequest: true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`POST /api/v3/repos/bar/foo/issues/12/comments {"body":"Released in v2.0.0"}`,
+		`POST /api/v3/repos/bar/foo/issues/12/labels ["released"]`,
+	}, calls)
+}
@@ -23,6 +23,9 @@ import (
 // GITLAB identifer for gitlab interface
 const GITLAB = "gitlab"
 
+// draftDuration until a draft is released if it is not published before
+const draftDuration = 365 * 24 * time.Hour
+
 // Client type struct
 type Client struct {
 	config  *config.GitLabProvider
@@ -32,16 +35,21 @@ type Client struct {
 	token   string
 	Release string
 	log     *log.Entry
+	// commit of the release commit, the release is created on this commit
+	commit string
+	// existing release, only missing assets are uploaded
+	existing bool
+	dryRun   *util.DryRun
 }
 
-// New initialize a new gitlabRelease
-func New(config *config.GitLabProvider, checkConfig bool) (*Client, error) {
+// New initialize a new gitlabRelease, calls are only recorded with dryRun
+func New(config *config.GitLabProvider, checkConfig bool, dryRun *util.DryRun) (*Client, error) {
 	accessToken, err := util.GetAccessToken(fmt.Sprintf("%s_ACCESS_TOKEN", strings.ToUpper(GITLAB)))
 	if err != nil && checkConfig {
 		return nil, err
 	}
 
-	tokenHeader := util.NewAddHeaderTransport(nil, "PRIVATE-TOKEN", accessToken)
+	tokenHeader := util.NewAddHeaderTransport(dryRun.Transport(), "PRIVATE-TOKEN", accessToken)
 	acceptHeader := util.NewAddHeaderTransport(tokenHeader, "Accept", "application/json")
 	contentHeader := util.NewAddHeaderTransport(acceptHeader, "Content-Type", "application/json")
 	httpClient := &http.Client{
@@ -73,6 +81,7 @@ func New(config *config.GitLabProvider, checkConfig bool) (*Client, error) {
 		apiURL:  config.CustomURL + "/api/v4",
 		client:  httpClient,
 		log:     logger,
+		dryRun:  dryRun,
 	}, nil
 }
 
@@ -102,18 +111,30 @@ func (g *Client) makeRelease(releaseVersion *shared.ReleaseVersion, generatedCha
 	if g.config.TagPrefix != nil{
 		tagPrefix = *g.config.TagPrefix
 	}
-	tag := tagPrefix + releaseVersion.Next.Version.String()
+	tag := tagPrefix + releaseVersion.Next.String()
 	g.Release = tag
 	g.log.Infof("create release with version %s", tag)
 	url := fmt.Sprintf("%s/projects/%s/releases", g.apiURL, util.PathEscape(g.config.Repo))
 	g.log.Infof("Send release to %s", url)
 
-	bodyBytes, err := json.Marshal(Release{
+	ref := releaseVersion.Branch
+	if g.commit != "" {
+		ref = g.commit
+	}
+
+	release := Release{
 		TagName:     tag,
 		Name:        generatedChangelog.Title,
 		Description: generatedChangelog.Content,
-		Ref:         releaseVersion.Branch,
-	})
+		Ref:         ref,
+	}
+	if g.config.Draft {
+		// releases with a future date are upcoming releases
+		releasedAt := time.Now().Add(draftDuration).UTC()
+		release.ReleasedAt = &releasedAt
+	}
+
+	bodyBytes, err := json.Marshal(release)
 	if err != nil {
 		return err
 	}
@@ -131,6 +152,12 @@ func (g *Client) makeRelease(releaseVersion *shared.ReleaseVersion, generatedCha
 		return fmt.Errorf("could not create release: %s", err.Error())
 	}
 
+	if resp.StatusCode == http.StatusConflict {
+		g.log.Infof("A release with tag %s already exits, will upload missing assets", tag)
+		g.existing = true
+		return nil
+	}
+
 	if err := util.IsValidResult(resp); err != nil {
 		return err
 	}
@@ -140,8 +167,228 @@ func (g *Client) makeRelease(releaseVersion *shared.ReleaseVersion, generatedCha
 	return nil
 }
 
+// PublishRelease releases the upcoming release of the version now
+func (g *Client) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	tagPrefix := config.DefaultTagPrefix
+	if g.config.TagPrefix != nil {
+		tagPrefix = *g.config.TagPrefix
+	}
+	tag := tagPrefix + releaseVersion.Next.String()
+	url := fmt.Sprintf("%s/projects/%s/releases/%s", g.apiURL, util.PathEscape(g.config.Repo), util.PathEscape(tag))
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	release := &ReleaseResult{}
+	resp, err := util.Do(g.client, req, release)
+	if err != nil {
+		return false, fmt.Errorf("could not get release %s: %s", tag, err.Error())
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return false, err
+	}
+
+	if !release.UpcomingRelease {
+		g.log.Infof("Release %s is already published", tag)
+		return false, nil
+	}
+
+	bodyBytes, err := json.Marshal(ReleaseUpdate{ReleasedAt: time.Now().UTC()})
+	if err != nil {
+		return false, err
+	}
+
+	req, err = http.NewRequest("PUT", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return false, fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	resp, err = util.Do(g.client, req, nil)
+	if err != nil {
+		return false, fmt.Errorf("could not publish release %s: %s", tag, err.Error())
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return false, err
+	}
+
+	g.log.Infof("Published release %s", tag)
+	releaseVersion.Next.Commit = release.Commit.ID
+	return true, nil
+}
+
+// CreateComments on the referenced issues and merge requests
+func (g *Client) CreateComments(releaseVersion *shared.ReleaseVersion, comments *shared.ReleaseComments) error {
+	for _, reference := range comments.References {
+		resource, prefix := "issues", "#"
+		if reference.MergeRequest {
+			resource, prefix = "merge_requests", "!"
+		}
+		url := fmt.Sprintf("%s/projects/%s/%s/%d", g.apiURL, util.PathEscape(g.config.Repo), resource, reference.Number)
+
+		if err := g.send("POST", url+"/notes", Note{Body: comments.Message}); err != nil {
+			return fmt.Errorf("could not comment on %s%d: %s", prefix, reference.Number, err.Error())
+		}
+
+		if comments.Label != "" {
+			if err := g.send("PUT", url, LabelUpdate{AddLabels: comments.Label}); err != nil {
+				return fmt.Errorf("could not add label %s to %s%d: %s", comments.Label, prefix, reference.Number, err.Error())
+			}
+		}
+		g.log.Infof("Commented release %s on %s%d", releaseVersion.Next.String(), prefix, reference.Number)
+	}
+	return nil
+}
+
+// send the body as json
+func (g *Client) send(method, url string, body interface{}) error {
+	bodyBytes, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(method, url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	resp, err := util.Do(g.client, req, nil)
+	if err != nil {
+		return err
+	}
+	return util.IsValidResult(resp)
+}
+
+// CreateCommit with the release files on the release branch
+func (g *Client) CreateCommit(releaseVersion *shared.ReleaseVersion, commit *shared.ReleaseCommit) (string, error) {
+	if err := g.checkBranch(releaseVersion); err != nil {
+		return "", err
+	}
+
+	actions := []CommitAction{}
+	for _, file := range commit.Files {
+		action, err := g.fileAction(releaseVersion.Branch, file.Path)
+		if err != nil {
+			return "", err
+		}
+		actions = append(actions, CommitAction{
+			Action:   action,
+			FilePath: file.Path,
+			Content:  string(file.Content),
+		})
+	}
+
+	bodyBytes, err := json.Marshal(Commit{
+		Branch:        releaseVersion.Branch,
+		CommitMessage: commit.Message,
+		Actions:       actions,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/repository/commits", g.apiURL, util.PathEscape(g.config.Repo))
+	req, err := http.NewRequest("POST", url, bytes.NewReader(bodyBytes))
+	if err != nil {
+		return "", fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	result := &CommitResult{}
+	resp, err := util.Do(g.client, req, result)
+	if err != nil {
+		return "", fmt.Errorf("could not create commit: %s", err.Error())
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return "", err
+	}
+
+	g.commit = result.ID
+	g.log.Infof("Created release commit %s", g.commit)
+	return g.commit, nil
+}
+
+// SetReleaseCommit which was created by a previous run, the release is created on it
+func (g *Client) SetReleaseCommit(releaseVersion *shared.ReleaseVersion, hash string) {
+	g.commit = hash
+}
+
+// checkBranch fails if the branch moved since the analyzed commit, the release commit would contain changes which are not part of the release
+func (g *Client) checkBranch(releaseVersion *shared.ReleaseVersion) error {
+	if releaseVersion.Next.Commit == "" {
+		return nil
+	}
+
+	url := fmt.Sprintf("%s/projects/%s/repository/branches/%s", g.apiURL, util.PathEscape(g.config.Repo), util.PathEscape(releaseVersion.Branch))
+	if g.dryRun != nil {
+		// the recorded branch has no commit to compare
+		g.dryRun.Record("check branch %s is on %s", releaseVersion.Branch, releaseVersion.Next.Commit)
+		return nil
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	branch := &Branch{}
+	resp, err := util.Do(g.client, req, branch)
+	if err != nil {
+		return fmt.Errorf("could not get branch %s: %s", releaseVersion.Branch, err.Error())
+	}
+
+	if err := util.IsValidResult(resp); err != nil {
+		return err
+	}
+
+	if branch.Commit.ID != releaseVersion.Next.Commit {
+		return fmt.Errorf("branch %s moved from %s to %s, could not create release commit", releaseVersion.Branch, releaseVersion.Next.Commit, branch.Commit.ID)
+	}
+	return nil
+}
+
+// fileAction for the commit, create if the file does not exist on the branch
+func (g *Client) fileAction(branch, path string) (string, error) {
+	url := fmt.Sprintf("%s/projects/%s/repository/files/%s?ref=%s", g.apiURL, util.PathEscape(g.config.Repo), util.PathEscape(path), util.PathEscape(branch))
+	req, err := http.NewRequest("HEAD", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not create request: %s", err.Error())
+	}
+
+	resp, err := util.Do(g.client, req, nil)
+	if err != nil {
+		return "", fmt.Errorf("could not check file %s: %s", path, err.Error())
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "create", nil
+	}
+	if err := util.IsValidResult(resp); err != nil {
+		return "", err
+	}
+	return "update", nil
+}
+
 func (g *Client) uploadAssets(assets *assets.Set) error {
+	linked := map[string]bool{}
+	if g.existing {
+		links, err := g.getLinks()
+		if err != nil {
+			return err
+		}
+		for _, link := range links {
+			linked[link.Name] = true
+		}
+	}
+
 	for _, asset := range assets.All() {
+		if linked[asset.GetName()] {
+			g.log.Infof("Asset %s is already linked with release %s", asset.GetName(), g.Release)
+			continue
+		}
 		path, err := asset.GetPath()
 		if err != nil {
 			return err
@@ -161,7 +408,7 @@ func (g *Client) uploadAssets(assets *assets.Set) error {
 
 		g.log.Infof("Uploaded file %s to gitlab can be downloaded under %s", file.Name(), downloadURL)
 
-		uploadURL := fmt.Sprintf("%s/projects/%s/releases/%s/assets/links?name=%s&url=%s", g.apiURL, util.PathEscape(g.config.Repo), g.Release, util.PathEscape(asset.GetName()), downloadURL)
+		uploadURL := fmt.Sprintf("%s/projects/%s/releases/%s/assets/links?name=%s&url=%s", g.apiURL, util.PathEscape(g.config.Repo), util.PathEscape(g.Release), util.PathEscape(asset.GetName()), downloadURL)
 
 		req, err := http.NewRequest("POST", uploadURL, nil)
 		if err != nil {
@@ -184,6 +431,26 @@ func (g *Client) uploadAssets(assets *assets.Set) error {
 	return nil
 }
 
+// getLinks of the assets of the release
+func (g *Client) getLinks() ([]ReleaseLink, error) {
+	url := fmt.Sprintf("%s/projects/%s/releases/%s/assets/links", g.apiURL, util.PathEscape(g.config.Repo), util.PathEscape(g.Release))
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	links := []ReleaseLink{}
+	resp, err := util.Do(g.client, req, &links)
+	if err != nil {
+		return nil, fmt.Errorf("could not get asset links of release %s: %s", g.Release, err.Error())
+	}
+
+	if err = util.IsValidResult(resp); err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
 func (g *Client) uploadFile(fileName string, file *os.File) (*ProjectFile, error) {
 
 	b := &bytes.Buffer{}
@@ -14,6 +14,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
 )
@@ -24,7 +25,7 @@ func TestGetCommitURL(t *testing.T) {
 	client, err := New(&config.GitLabProvider{
 		CustomURL: "https://127.0.0.1/",
 		Repo:      "test/test",
-	}, true)
+	}, true, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "https://127.0.0.1/test/test/commit/{{hash}}", client.GetCommitURL())
 }
@@ -35,7 +36,7 @@ func TestGetCompareURL(t *testing.T) {
 	client, err := New(&config.GitLabProvider{
 		CustomURL: "https://127.0.0.1/",
 		Repo:      "test/test",
-	}, true)
+	}, true, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "https://127.0.0.1/test/test/compare/1.0.0...1.0.1", client.GetCompareURL("1.0.0", "1.0.1"))
 }
@@ -45,7 +46,7 @@ func TestValidateConfig_EmptyRepro(t *testing.T) {
 	defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
 	_, err := New(&config.GitLabProvider{
 		CustomURL: "https://127.0.0.1/",
-	}, true)
+	}, true, nil)
 	assert.Error(t, err)
 }
 
@@ -55,7 +56,7 @@ func TestValidateConfig_DefaultURL(t *testing.T) {
 	config := &config.GitLabProvider{
 		Repo: "127.0.0.1/test",
 	}
-	_, err := New(config, true)
+	_, err := New(config, true, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "https://gitlab.com", config.CustomURL)
 }
@@ -67,7 +68,7 @@ func TestValidateConfig_CustomURL(t *testing.T) {
 		Repo:      "/127.0.0.1/test/",
 		CustomURL: "https://127.0.0.1/",
 	}
-	_, err := New(config, true)
+	_, err := New(config, true, nil)
 	assert.NoError(t, err)
 	assert.Equal(t, "https://127.0.0.1", config.CustomURL)
 	assert.Equal(t, "127.0.0.1/test", config.Repo)
@@ -190,7 +191,7 @@ func TestCreateRelease(t *testing.T) {
 		}
 		os.Setenv("GITLAB_ACCESS_TOKEN", "aToken")
 		defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
-		client, err := New(&testObject.config, false)
+		client, err := New(&testObject.config, false, nil)
 		assert.NoError(t, err)
 
 		err = client.makeRelease(testObject.releaseVersion, testObject.generatedChangelog)
@@ -234,7 +235,7 @@ func TestUploadAssets(t *testing.T) {
 			responseCode: []int{200, 200},
 			requestBody: []string{
 				filepath.Base(file.Name()), ""},
-			url:     []string{`/api/v4/projects/foo%2Fbar/uploads`, "/api/v4/projects/foo%2Fbar/releases/1.0.0/assets/links?name=" + filepath.Base(file.Name()) + "&url=<SERVER>/foo/bar/uploads/"},
+			url:     []string{`/api/v4/projects/foo%2Fbar/uploads`, "/api/v4/projects/foo%2Fbar/releases/1%2E0%2E0/assets/links?name=" + filepath.Base(file.Name()) + "&url=<SERVER>/foo/bar/uploads/"},
 			method:  []string{"POST", "POST"},
 			valid:   true,
 			testDir: os.TempDir(),
@@ -253,7 +254,7 @@ func TestUploadAssets(t *testing.T) {
 			responseCode: []int{400, 200},
 			requestBody: []string{
 				filepath.Base(file.Name()), ""},
-			url:     []string{`/api/v4/projects/foo%2Fbar/uploads`, "/api/v4/projects/foo%2Fbar/releases/1.0.0/assets/links?name=" + filepath.Base(file.Name()) + "&url=<SERVER>/foo/bar/uploads/"},
+			url:     []string{`/api/v4/projects/foo%2Fbar/uploads`, "/api/v4/projects/foo%2Fbar/releases/1%2E0%2E0/assets/links?name=" + filepath.Base(file.Name()) + "&url=<SERVER>/foo/bar/uploads/"},
 			method:  []string{"POST", "POST"},
 			valid:   false,
 			testDir: os.TempDir(),
@@ -272,7 +273,7 @@ func TestUploadAssets(t *testing.T) {
 			responseCode: []int{200, 200},
 			requestBody: []string{
 				filepath.Base(file.Name()), ""},
-			url:     []string{`/api/v4/projects/foo%2Fbar/uploads`, "/api/v4/projects/foo%2Fbar/releases/1.0.0/assets/links?name=" + filepath.Base(file.Name()) + "&url=<SERVER>/foo/bar/uploads/"},
+			url:     []string{`/api/v4/projects/foo%2Fbar/uploads`, "/api/v4/projects/foo%2Fbar/releases/1%2E0%2E0/assets/links?name=" + filepath.Base(file.Name()) + "&url=<SERVER>/foo/bar/uploads/"},
 			method:  []string{"POST", "POST"},
 			valid:   false,
 			testDir: os.TempDir(),
@@ -317,7 +318,7 @@ func TestUploadAssets(t *testing.T) {
 		}
 		os.Setenv("GITLAB_ACCESS_TOKEN", "aToken")
 		defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
-		client, err := New(&testObject.config, false)
+		client, err := New(&testObject.config, false, nil)
 		assert.NoError(t, err)
 		client.Release = "1.0.0"
 
@@ -336,3 +337,183 @@ func TestUploadAssets(t *testing.T) {
 
 	}
 }
+
+func TestCreateCommit(t *testing.T) {
+	calls := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		bodyBytes, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calls = append(calls, strings.TrimSpace(req.Method+" "+req.URL.RequestURI()+" "+string(bodyBytes)))
+		switch {
+		case req.Method == "HEAD" && strings.Contains(req.URL.Path, "package.json"):
+			rw.WriteHeader(http.StatusOK)
+		case req.Method == "HEAD":
+			rw.WriteHeader(http.StatusNotFound)
+		case req.Method == "GET":
+			_, err = rw.Write([]byte(`{"commit":{"id":"def"}}`))
+			assert.NoError(t, err)
+		default:
+			rw.WriteHeader(http.StatusCreated)
+			_, err = rw.Write([]byte(`{"id":"abc"}`))
+			assert.NoError(t, err)
+		}
+	}))
+	defer testServer.Close()
+
+	os.Setenv("GITLAB_ACCESS_TOKEN", "aToken")
+	defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
+	client, err := New(&config.GitLabProvider{Repo: "foo/bar", CustomURL: testServer.URL}, false, nil)
+	assert.NoError(t, err)
+
+	releaseCommit := &shared.ReleaseCommit{
+		Message: "chore(release): 2.0.0",
+		Files: []shared.ReleaseFile{
+			{Path: "package.json", Content: []byte("{}")},
+			{Path: "CHANGELOG.md", Content: []byte("# 2.0.0")},
+		},
+	}
+	hash, err := client.CreateCommit(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Commit: "def"}, Branch: "master"}, releaseCommit)
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", hash)
+	assert.Equal(t, "abc", client.commit)
+	assert.Equal(t, []string{
+		"GET /api/v4/projects/foo%2Fbar/repository/branches/master",
+		"HEAD /api/v4/projects/foo%2Fbar/repository/files/package%2Ejson?ref=master",
+		"HEAD /api/v4/projects/foo%2Fbar/repository/files/CHANGELOG%2Emd?ref=master",
+		`POST /api/v4/projects/foo%2Fbar/repository/commits {"branch":"master","commit_message":"chore(release): 2.0.0","actions":[{"action":"update","file_path":"package.json","content":"{}"},{"action":"create","file_path":"CHANGELOG.md","content":"# 2.0.0"}]}`,
+	}, calls)
+
+	calls = []string{}
+	client.commit = ""
+	_, err = client.CreateCommit(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Commit: "abc"}, Branch: "master"}, releaseCommit)
+	assert.Error(t, err)
+	assert.Empty(t, client.commit)
+	assert.Equal(t, []string{"GET /api/v4/projects/foo%2Fbar/repository/branches/master"}, calls)
+}
+
+func TestCreateCommit_DryRun(t *testing.T) {
+	dryRun := util.NewDryRun()
+	client, err := New(&config.GitLabProvider{Repo: "foo/bar"}, false, dryRun)
+	assert.NoError(t, err)
+
+	_, err = client.CreateCommit(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Commit: "def"}, Branch: "master"}, &shared.ReleaseCommit{
+		Message: "chore(release): 2.0.0",
+		Files:   []shared.ReleaseFile{{Path: "CHANGELOG.md", Content: []byte("# 2.0.0")}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "check branch master is on def", dryRun.Calls()[0])
+	assert.Len(t, dryRun.Calls(), 3)
+}
+
+func TestCreateRelease_Resume(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.txt", "b.txt"} {
+		assert.NoError(t, ioutil.WriteFile(filepath.Join(dir, name), []byte(name), 0644))
+	}
+	set := assets.New(dir, "")
+	assert.NoError(t, set.Add(config.Asset{Name: "a.txt"}, config.Asset{Name: "b.txt"}))
+
+	calls := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls = append(calls, req.Method+" "+req.URL.EscapedPath())
+		switch {
+		case req.URL.EscapedPath() == "/api/v4/projects/foo%2Fbar/releases":
+			rw.WriteHeader(http.StatusConflict)
+		case req.Method == "GET":
+			_, err := rw.Write([]byte(`[{"name":"a.txt","url":"/uploads/1/a.txt"}]`))
+			assert.NoError(t, err)
+		default:
+			_, err := rw.Write([]byte(`{"url":"/uploads/2/b.txt"}`))
+			assert.NoError(t, err)
+		}
+	}))
+	defer testServer.Close()
+
+	os.Setenv("GITLAB_ACCESS_TOKEN", "aToken")
+	defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
+	tagPrefix := "api/v"
+	client, err := New(&config.GitLabProvider{Repo: "foo/bar", CustomURL: testServer.URL, TagPrefix: &tagPrefix}, false, nil)
+	assert.NoError(t, err)
+
+	err = client.CreateRelease(&shared.ReleaseVersion{
+		Next:   shared.ReleaseVersionEntry{Version: semver.MustParse("2.0.0")},
+		Branch: "master",
+	}, &shared.GeneratedChangelog{Title: "title", Content: "content"}, set)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /api/v4/projects/foo%2Fbar/releases",
+		"GET /api/v4/projects/foo%2Fbar/releases/api%2Fv2%2E0%2E0/assets/links",
+		"POST /api/v4/projects/foo%2Fbar/uploads",
+		"POST /api/v4/projects/foo%2Fbar/releases/api%2Fv2%2E0%2E0/assets/links",
+	}, calls)
+}
+
+func TestPublishRelease(t *testing.T) {
+	calls := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		bodyBytes, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calls = append(calls, req.Method+" "+req.URL.EscapedPath())
+		switch req.Method {
+		case "POST":
+			assert.Contains(t, string(bodyBytes), `"released_at":"`)
+		case "GET":
+			_, err = rw.Write([]byte(`{"tag_name":"v2.0.0","upcoming_release":true,"commit":{"id":"abc"}}`))
+			assert.NoError(t, err)
+		case "PUT":
+			assert.Contains(t, string(bodyBytes), `"released_at":"`)
+		}
+	}))
+	defer testServer.Close()
+
+	os.Setenv("GITLAB_ACCESS_TOKEN", "aToken")
+	defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
+	client, err := New(&config.GitLabProvider{Repo: "foo/bar", CustomURL: testServer.URL, Draft: true}, false, nil)
+	assert.NoError(t, err)
+
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("2.0.0")}, Branch: "master"}
+	assert.NoError(t, client.makeRelease(releaseVersion, &shared.GeneratedChangelog{Title: "title", Content: "content"}))
+	published, err := client.PublishRelease(releaseVersion)
+	assert.NoError(t, err)
+	assert.True(t, published)
+	assert.Equal(t, "abc", releaseVersion.Next.Commit)
+	assert.Equal(t, []string{
+		"POST /api/v4/projects/foo%2Fbar/releases",
+		"GET /api/v4/projects/foo%2Fbar/releases/v2%2E0%2E0",
+		"PUT /api/v4/projects/foo%2Fbar/releases/v2%2E0%2E0",
+	}, calls)
+}
+
+func TestCreateComments(t *testing.T) {
+	calls := []string{}
+	testServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		bodyBytes, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			log.Fatal(err)
+		}
+		calls = append(calls, req.Method+" "+req.URL.EscapedPath()+" "+string(bodyBytes))
+	}))
+	defer testServer.Close()
+
+	os.Setenv("GITLAB_ACCESS_TOKEN", "aToken")
+	defer os.Unsetenv("GITLAB_ACCESS_TOKEN")
+	client, err := New(&config.GitLabProvider{Repo: "foo/bar", CustomURL: testServer.URL}, false, nil)
+	assert.NoError(t, err)
+
+	err = client.CreateComments(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("2.0.0")}}, &shared.ReleaseComments{
+		Message:    "Released in v2.0.0",
+		Label:      "released",
+		References: []shared.Reference{{Number: 12}, {Number: 7, MergeRequest: true}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`POST /api/v4/projects/foo%2Fbar/issues/12/notes {"body":"Released in v2.0.0"}`,
+		`PUT /api/v4/projects/foo%2Fbar/issues/12 {"add_labels":"released"}`,
+		`POST /api/v4/projects/foo%2Fbar/merge_requests/7/notes {"body":"Released in v2.0.0"}`,
+		`PUT /api/v4/projects/foo%2Fbar/merge_requests/7 {"add_labels":"released"}`,
+	}, calls)
+}
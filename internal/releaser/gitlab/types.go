@@ -1,11 +1,26 @@
 package gitlab
 
+import "time"
+
 // Release struct
 type Release struct {
-	TagName     string `json:"tag_name"`
-	Name        string `json:"name"`
-	Ref         string `json:"ref"`
-	Description string `json:"description,omitempty"`
+	TagName     string     `json:"tag_name"`
+	Name        string     `json:"name"`
+	Ref         string     `json:"ref"`
+	Description string     `json:"description,omitempty"`
+	ReleasedAt  *time.Time `json:"released_at,omitempty"`
+}
+
+// ReleaseUpdate struct
+type ReleaseUpdate struct {
+	ReleasedAt time.Time `json:"released_at"`
+}
+
+// ReleaseResult struct
+type ReleaseResult struct {
+	TagName         string       `json:"tag_name"`
+	UpcomingRelease bool         `json:"upcoming_release"`
+	Commit          CommitResult `json:"commit"`
 }
 
 // ReleaseLink struct
@@ -20,3 +35,37 @@ type ProjectFile struct {
 	URL      string `json:"url"`
 	Markdown string `json:"markdown"`
 }
+
+// Commit struct
+type Commit struct {
+	Branch        string         `json:"branch"`
+	CommitMessage string         `json:"commit_message"`
+	Actions       []CommitAction `json:"actions"`
+}
+
+// CommitAction struct
+type CommitAction struct {
+	Action   string `json:"action"`
+	FilePath string `json:"file_path"`
+	Content  string `json:"content"`
+}
+
+// CommitResult struct
+type CommitResult struct {
+	ID string `json:"id"`
+}
+
+// Branch struct
+type Branch struct {
+	Commit CommitResult `json:"commit"`
+}
+
+// Note struct
+type Note struct {
+	Body string `json:"body"`
+}
+
+// LabelUpdate struct
+type LabelUpdate struct {
+	AddLabels string `json:"add_labels"`
+}
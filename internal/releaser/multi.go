@@ -0,0 +1,159 @@
+package releaser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/journal"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// PolicyAbort stops at the first failed release, default
+	PolicyAbort = "abort"
+	// PolicyContinue releases to all providers and fails afterwards if one release failed
+	PolicyContinue = "continue"
+	// PolicyPrimary only fails if the release of the first provider failed
+	PolicyPrimary = "primary"
+)
+
+// namedReleaser is a releaser with the name of its provider
+type namedReleaser struct {
+	name     string
+	releaser Releaser
+}
+
+// Multi releases to several providers in the configured order, urls are taken from the first (primary) provider
+type Multi struct {
+	releasers []namedReleaser
+	policy    string
+	journal   *journal.Journal
+}
+
+func newMulti(releasers []namedReleaser, policy string) (*Multi, error) {
+	switch policy {
+	case "":
+		policy = PolicyAbort
+	case PolicyAbort, PolicyContinue, PolicyPrimary:
+	default:
+		return nil, fmt.Errorf("release policy %s is not supported, use %s, %s or %s", policy, PolicyAbort, PolicyContinue, PolicyPrimary)
+	}
+
+	names := map[string]bool{}
+	for _, r := range releasers {
+		if names[r.name] {
+			return nil, fmt.Errorf("releaser %s is configured more than once", r.name)
+		}
+		names[r.name] = true
+	}
+	return &Multi{
+		releasers: releasers,
+		policy:    policy,
+	}, nil
+}
+
+// GetCommitURL of the primary provider
+func (m *Multi) GetCommitURL() string {
+	return m.releasers[0].releaser.GetCommitURL()
+}
+
+// GetCompareURL of the primary provider
+func (m *Multi) GetCompareURL(oldVersion, newVersion string) string {
+	return m.releasers[0].releaser.GetCompareURL(oldVersion, newVersion)
+}
+
+// SetJournal to skip providers which already released this version
+func (m *Multi) SetJournal(journal *journal.Journal) {
+	m.journal = journal
+}
+
+// CreateCommit with the primary provider only, the commit is pushed once
+func (m *Multi) CreateCommit(releaseVersion *shared.ReleaseVersion, commit *shared.ReleaseCommit) (string, error) {
+	committer, ok := m.releasers[0].releaser.(Committer)
+	if !ok {
+		return "", fmt.Errorf("release commits are not supported by %s", m.releasers[0].name)
+	}
+	return committer.CreateCommit(releaseVersion, commit)
+}
+
+// SetReleaseCommit of the primary provider
+func (m *Multi) SetReleaseCommit(releaseVersion *shared.ReleaseVersion, hash string) {
+	if committer, ok := m.releasers[0].releaser.(Committer); ok {
+		committer.SetReleaseCommit(releaseVersion, hash)
+	}
+}
+
+// CreateComments with the primary provider only, issues and merge requests belong to it
+func (m *Multi) CreateComments(releaseVersion *shared.ReleaseVersion, comments *shared.ReleaseComments) error {
+	commenter, ok := m.releasers[0].releaser.(Commenter)
+	if !ok {
+		log.Warnf("Comments are not supported by %s, skip comments", m.releasers[0].name)
+		return nil
+	}
+	return commenter.CreateComments(releaseVersion, comments)
+}
+
+// CreateRelease on all providers, a failure is handled by the release policy
+func (m *Multi) CreateRelease(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog, assets *assets.Set) error {
+	results := map[string]error{}
+	failed := []string{}
+
+	for i, r := range m.releasers {
+		releaser := r.releaser
+		err := m.journal.Run("release "+r.name, func() error {
+			return releaser.CreateRelease(releaseVersion, generatedChangelog, assets)
+		})
+		results[r.name] = err
+		if err == nil {
+			log.Infof("Release to %s succeeded", r.name)
+			continue
+		}
+
+		log.Errorf("Release to %s failed: %s", r.name, err.Error())
+		failed = append(failed, r.name)
+
+		if m.policy == PolicyAbort || (m.policy == PolicyPrimary && i == 0) {
+			m.report(results)
+			return fmt.Errorf("release to %s failed, skip remaining releasers: %w", r.name, err)
+		}
+	}
+
+	m.report(results)
+
+	if len(failed) == 0 || m.policy == PolicyPrimary {
+		return nil
+	}
+	return fmt.Errorf("release to %s failed", strings.Join(failed, ", "))
+}
+
+// PublishRelease on all providers, stops at the first failure, returns true if the primary provider published a draft
+func (m *Multi) PublishRelease(releaseVersion *shared.ReleaseVersion) (bool, error) {
+	primaryPublished := false
+	for i, r := range m.releasers {
+		published, err := r.releaser.PublishRelease(releaseVersion)
+		if err != nil {
+			return false, fmt.Errorf("publish on %s failed: %w", r.name, err)
+		}
+		if i == 0 {
+			primaryPublished = published
+		}
+	}
+	return primaryPublished, nil
+}
+
+// report logs the result of each provider, providers without result were skipped
+func (m *Multi) report(results map[string]error) {
+	for _, r := range m.releasers {
+		err, ok := results[r.name]
+		switch {
+		case !ok:
+			log.Infof("%s: skipped", r.name)
+		case err != nil:
+			log.Infof("%s: failed (%s)", r.name, err.Error())
+		default:
+			log.Infof("%s: released", r.name)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package releaser
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/Masterminds/semver"
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/journal"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+	"github.com/stretchr/testify/assert"
+)
+
+type testReleaser struct {
+	url       string
+	err       error
+	calls     int
+	published bool
+}
+
+func (t *testReleaser) CreateRelease(*shared.ReleaseVersion, *shared.GeneratedChangelog, *assets.Set) error {
+	t.calls++
+	return t.err
+}
+
+func (t *testReleaser) PublishRelease(*shared.ReleaseVersion) (bool, error) {
+	return t.published, t.err
+}
+
+func (t *testReleaser) GetCommitURL() string {
+	return t.url
+}
+
+func (t *testReleaser) GetCompareURL(oldVersion, newVersion string) string {
+	return t.url
+}
+
+func TestMulti_CreateRelease(t *testing.T) {
+
+	testConfigs := []struct {
+		testCase string
+		policy   string
+		errors   []error
+		calls    []int
+		hasError bool
+	}{
+		{
+			testCase: "all succeed",
+			errors:   []error{nil, nil, nil},
+			calls:    []int{1, 1, 1},
+		},
+		{
+			testCase: "abort",
+			policy:   PolicyAbort,
+			errors:   []error{nil, fmt.Errorf("failed"), nil},
+			calls:    []int{1, 1, 0},
+			hasError: true,
+		},
+		{
+			testCase: "continue",
+			policy:   PolicyContinue,
+			errors:   []error{fmt.Errorf("failed"), nil, nil},
+			calls:    []int{1, 1, 1},
+			hasError: true,
+		},
+		{
+			testCase: "primary succeeds",
+			policy:   PolicyPrimary,
+			errors:   []error{nil, fmt.Errorf("failed"), nil},
+			calls:    []int{1, 1, 1},
+		},
+		{
+			testCase: "primary fails",
+			policy:   PolicyPrimary,
+			errors:   []error{fmt.Errorf("failed"), nil, nil},
+			calls:    []int{1, 0, 0},
+			hasError: true,
+		},
+	}
+
+	for _, test := range testConfigs {
+		t.Run(test.testCase, func(t *testing.T) {
+			releasers := []*testReleaser{}
+			named := []namedReleaser{}
+			for i, err := range test.errors {
+				r := &testReleaser{err: err, url: fmt.Sprintf("url%d", i)}
+				releasers = append(releasers, r)
+				named = append(named, namedReleaser{name: fmt.Sprintf("releaser%d", i), releaser: r})
+			}
+
+			multi, err := newMulti(named, test.policy)
+			assert.NoError(t, err)
+			assert.Equal(t, "url0", multi.GetCommitURL())
+
+			err = multi.CreateRelease(&shared.ReleaseVersion{}, &shared.GeneratedChangelog{}, nil)
+			assert.Equal(t, test.hasError, err != nil)
+			for i, r := range releasers {
+				assert.Equalf(t, test.calls[i], r.calls, "releaser%d", i)
+			}
+		})
+	}
+
+	_, err := newMulti([]namedReleaser{}, "ignore")
+	assert.Error(t, err)
+
+	_, err = newMulti([]namedReleaser{{name: "github", releaser: &testReleaser{}}, {name: "github", releaser: &testReleaser{}}}, PolicyAbort)
+	assert.Error(t, err)
+}
+
+func TestMulti_CreateRelease_Journal(t *testing.T) {
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0")}}
+	j, err := journal.Read(filepath.Join(t.TempDir(), "repo"), releaseVersion)
+	assert.NoError(t, err)
+
+	github := &testReleaser{}
+	gitlab := &testReleaser{err: fmt.Errorf("failed")}
+	multi, err := newMulti([]namedReleaser{{name: "github", releaser: github}, {name: "gitlab", releaser: gitlab}}, PolicyContinue)
+	assert.NoError(t, err)
+	multi.SetJournal(j)
+
+	assert.Error(t, multi.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil))
+	gitlab.err = nil
+	assert.NoError(t, multi.CreateRelease(releaseVersion, &shared.GeneratedChangelog{}, nil))
+
+	assert.Equal(t, 1, github.calls)
+	assert.Equal(t, 2, gitlab.calls)
+}
+
+func TestMulti_PublishRelease(t *testing.T) {
+	multi, err := newMulti([]namedReleaser{{name: "github", releaser: &testReleaser{}}, {name: "gitlab", releaser: &testReleaser{published: true}}}, PolicyAbort)
+	assert.NoError(t, err)
+	published, err := multi.PublishRelease(&shared.ReleaseVersion{})
+	assert.NoError(t, err)
+	assert.False(t, published, "only the primary releaser counts")
+
+	multi, err = newMulti([]namedReleaser{{name: "github", releaser: &testReleaser{published: true}}, {name: "gitlab", releaser: &testReleaser{err: fmt.Errorf("failed")}}}, PolicyAbort)
+	assert.NoError(t, err)
+	_, err = multi.PublishRelease(&shared.ReleaseVersion{})
+	assert.Error(t, err)
+}
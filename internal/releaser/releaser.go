@@ -5,9 +5,14 @@ import (
 
 	"github.com/Nightapes/go-semantic-release/internal/assets"
 	"github.com/Nightapes/go-semantic-release/internal/gitutil"
+	"github.com/Nightapes/go-semantic-release/internal/journal"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/azure"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/bitbucket"
 	"github.com/Nightapes/go-semantic-release/internal/releaser/git"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/gitea"
 	"github.com/Nightapes/go-semantic-release/internal/releaser/github"
 	"github.com/Nightapes/go-semantic-release/internal/releaser/gitlab"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 
 	"github.com/Nightapes/go-semantic-release/pkg/config"
@@ -16,17 +21,38 @@ import (
 
 // Releasers struct type
 type Releasers struct {
-	config *config.ReleaseConfig
-	git    *gitutil.GitUtil
+	config  *config.ReleaseConfig
+	git     *gitutil.GitUtil
+	dryRuns map[string]*util.DryRun
 }
 
 // Releaser interface for providers
 type Releaser interface {
 	CreateRelease(*shared.ReleaseVersion, *shared.GeneratedChangelog, *assets.Set) error
+	// PublishRelease returns true if a draft was published, the commit of the draft is set as next commit if known
+	PublishRelease(*shared.ReleaseVersion) (bool, error)
 	GetCommitURL() string
 	GetCompareURL(oldVersion, newVersion string) string
 }
 
+// Committer is implemented by releasers which can push a release commit, the release is created on this commit
+type Committer interface {
+	// CreateCommit returns the hash of the release commit
+	CreateCommit(*shared.ReleaseVersion, *shared.ReleaseCommit) (string, error)
+	// SetReleaseCommit restores the release commit of a resumed release
+	SetReleaseCommit(releaseVersion *shared.ReleaseVersion, hash string)
+}
+
+// Commenter is implemented by releasers which can comment on the issues and merge requests of a release
+type Commenter interface {
+	CreateComments(*shared.ReleaseVersion, *shared.ReleaseComments) error
+}
+
+// Journaled is implemented by releasers which record the release of each provider in the journal
+type Journaled interface {
+	SetJournal(*journal.Journal)
+}
+
 // New initialize a releaser
 func New(c *config.ReleaseConfig, git *gitutil.GitUtil) *Releasers {
 	return &Releasers{
@@ -35,18 +61,66 @@ func New(c *config.ReleaseConfig, git *gitutil.GitUtil) *Releasers {
 	}
 }
 
-//GetReleaser returns an initialized releaser
+//GetReleaser returns an initialized releaser, several configured releasers are combined to one
 func (r *Releasers) GetReleaser(checkConfig bool) (Releaser, error) {
-	switch r.config.Release {
+	names := r.config.GetReleases()
+	switch len(names) {
+	case 0:
+		return r.getReleaser("", checkConfig)
+	case 1:
+		return r.getReleaser(names[0], checkConfig)
+	}
+
+	releasers := make([]namedReleaser, 0, len(names))
+	for _, name := range names {
+		releaser, err := r.getReleaser(name, checkConfig)
+		if err != nil {
+			return nil, err
+		}
+		releasers = append(releasers, namedReleaser{name: name, releaser: releaser})
+	}
+	return newMulti(releasers, r.config.ReleasePolicy)
+}
+
+//GetDryRunReleaser returns a releaser which only records its calls, the calls are returned by provider name
+func (r *Releasers) GetDryRunReleaser(checkConfig bool) (Releaser, map[string]*util.DryRun, error) {
+	r.dryRuns = map[string]*util.DryRun{}
+	releaser, err := r.GetReleaser(checkConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	return releaser, r.dryRuns, nil
+}
+
+// dryRun of the provider, nil if calls should be sent
+func (r *Releasers) dryRun(name string) *util.DryRun {
+	if r.dryRuns == nil {
+		return nil
+	}
+	r.dryRuns[name] = util.NewDryRun()
+	return r.dryRuns[name]
+}
+
+func (r *Releasers) getReleaser(name string, checkConfig bool) (Releaser, error) {
+	switch name {
 	case github.GITHUB:
 		log.Debugf("initialize new %s-provider", github.GITHUB)
-		return github.New(&r.config.GitHubProvider, checkConfig)
+		return github.New(&r.config.GitHubProvider, checkConfig, r.dryRun(name))
 	case gitlab.GITLAB:
 		log.Debugf("initialize new %s-provider", gitlab.GITLAB)
-		return gitlab.New(&r.config.GitLabProvider, checkConfig)
+		return gitlab.New(&r.config.GitLabProvider, checkConfig, r.dryRun(name))
+	case gitea.GITEA:
+		log.Debugf("initialize new %s-provider", gitea.GITEA)
+		return gitea.New(&r.config.GiteaProvider, checkConfig, r.dryRun(name))
+	case bitbucket.BITBUCKET:
+		log.Debugf("initialize new %s-provider", bitbucket.BITBUCKET)
+		return bitbucket.New(&r.config.BitbucketProvider, checkConfig, r.dryRun(name))
+	case azure.AZURE:
+		log.Debugf("initialize new %s-provider", azure.AZURE)
+		return azure.New(&r.config.AzureProvider, checkConfig, r.dryRun(name))
 	case git.GITONLY:
 		log.Debugf("initialize new %s-provider", git.GITONLY)
-		return git.New(&r.config.GitProvider, r.git, checkConfig)
+		return git.New(&r.config.GitProvider, r.git, checkConfig, r.dryRun(name))
 	}
-	return nil, fmt.Errorf("could not initialize a releaser from this type: %s", r.config.Release)
+	return nil, fmt.Errorf("could not initialize a releaser from this type: %s", name)
 }
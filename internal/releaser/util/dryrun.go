@@ -0,0 +1,65 @@
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DryRun records the calls of a releaser instead of sending them
+type DryRun struct {
+	calls []string
+}
+
+// NewDryRun without recorded calls
+func NewDryRun() *DryRun {
+	return &DryRun{calls: []string{}}
+}
+
+// Transport for the http client of a releaser, nil without dry run to use the default transport
+func (d *DryRun) Transport() http.RoundTripper {
+	if d == nil {
+		return nil
+	}
+	return d
+}
+
+// RoundTrip records the request and answers with an empty json object, json bodies are recorded too
+func (d *DryRun) RoundTrip(req *http.Request) (*http.Response, error) {
+	call := fmt.Sprintf("%s %s", req.Method, req.URL.String())
+	if req.Body != nil {
+		body, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") && len(body) > 0 {
+			call += "\n" + strings.TrimSpace(string(body))
+		}
+	}
+	d.calls = append(d.calls, call)
+
+	status := http.StatusOK
+	if req.Method == http.MethodPost {
+		status = http.StatusCreated
+	}
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewBufferString("{}")),
+		Request:    req,
+	}, nil
+}
+
+// Record a call which is not made via http, like a git push
+func (d *DryRun) Record(format string, a ...interface{}) {
+	d.calls = append(d.calls, fmt.Sprintf(format, a...))
+}
+
+// Calls recorded so far
+func (d *DryRun) Calls() []string {
+	return d.calls
+}
@@ -153,3 +153,22 @@ func TestDoAndRoundTrip(t *testing.T) {
 		}
 	}
 }
+
+func TestDryRun(t *testing.T) {
+	dryRun := util.NewDryRun()
+	client := &http.Client{Transport: util.NewAddHeaderTransport(dryRun.Transport(), "Content-Type", "application/json")}
+
+	req, err := http.NewRequest("POST", "https://example.com/releases", strings.NewReader(`{"tag_name":"v1.0.0"}`))
+	assert.NoError(t, err)
+	result := map[string]string{}
+	resp, err := util.Do(client, req, &result)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	dryRun.Record("git push %s", "origin")
+
+	assert.Equal(t, []string{"POST https://example.com/releases\n{\"tag_name\":\"v1.0.0\"}", "git push origin"}, dryRun.Calls())
+
+	var noDryRun *util.DryRun
+	assert.Nil(t, noDryRun.Transport())
+}
@@ -1,6 +1,8 @@
 package shared
 
 import (
+	"time"
+
 	"github.com/Masterminds/semver"
 )
 
@@ -19,12 +21,48 @@ type ReleaseVersionEntry struct {
 	Version       *semver.Version `yaml:"-"`
 }
 
+// String of the version, VersionString is used if set to keep formats like 2024.05.0
+func (r ReleaseVersionEntry) String() string {
+	if r.VersionString != "" {
+		return r.VersionString
+	}
+	if r.Version != nil {
+		return r.Version.String()
+	}
+	return ""
+}
+
 //GeneratedChangelog struct
 type GeneratedChangelog struct {
 	Title   string
 	Content string
 }
 
+//ReleaseCommit struct with the files changed by a release
+type ReleaseCommit struct {
+	Message string
+	Files   []ReleaseFile
+}
+
+//ReleaseFile struct, path is relative to the repository
+type ReleaseFile struct {
+	Path    string
+	Content []byte
+}
+
+//ReleaseComments struct, the message is commented on each referenced issue and merge request
+type ReleaseComments struct {
+	Message    string
+	Label      string
+	References []Reference
+}
+
+// Reference to an issue or merge request, pull requests of github are issues
+type Reference struct {
+	Number       int
+	MergeRequest bool
+}
+
 //ChangelogTemplateConfig struct
 type ChangelogTemplateConfig struct {
 	CommitURL  string
@@ -61,7 +99,12 @@ type Release string
 
 // Commit struct
 type Commit struct {
-	Message string `yaml:"message"`
-	Author  string `yaml:"author"`
-	Hash    string `yaml:"hash"`
+	Message        string    `yaml:"message"`
+	Author         string    `yaml:"author"`
+	AuthorEmail    string    `yaml:"authorEmail,omitempty"`
+	AuthorDate     time.Time `yaml:"authorDate,omitempty"`
+	Committer      string    `yaml:"committer,omitempty"`
+	CommitterEmail string    `yaml:"committerEmail,omitempty"`
+	CommitterDate  time.Time `yaml:"committerDate,omitempty"`
+	Hash           string    `yaml:"hash"`
 }
@@ -2,8 +2,10 @@
 package config
 
 import (
+	"fmt"
 	"io/ioutil"
 	"os"
+	"regexp"
 
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
@@ -15,7 +17,48 @@ const (
 
 // AnalyzerConfig struct
 type AnalyzerConfig struct {
-	TokenSeparators []string `yaml:"tokenSeparators"`
+	TokenSeparators []string             `yaml:"tokenSeparators"`
+	Rules           []AnalyzerRule       `yaml:"rules,omitempty"`
+	Custom          AnalyzerCustomFormat `yaml:"custom,omitempty"`
+}
+
+// AnalyzerCustomFormat struct, header regex and breaking change keywords for the custom commit format
+type AnalyzerCustomFormat struct {
+	Regex                  string   `yaml:"regex,omitempty"`
+	BreakingChangeKeywords []string `yaml:"breakingChangeKeywords,omitempty"`
+}
+
+// Validate checks if the regex compiles and contains the required named groups
+func (c AnalyzerCustomFormat) Validate() error {
+	if c.Regex == "" {
+		return fmt.Errorf("custom commit format needs a regex (analyzer.custom.regex)")
+	}
+
+	regex, err := regexp.Compile(c.Regex)
+	if err != nil {
+		return fmt.Errorf("invalid regex for custom commit format: %w", err)
+	}
+
+	groups := map[string]bool{}
+	for _, name := range regex.SubexpNames() {
+		groups[name] = true
+	}
+
+	for _, required := range []string{"type", "subject"} {
+		if !groups[required] {
+			return fmt.Errorf("regex %s for custom commit format is missing the named group (?P<%s>...)", c.Regex, required)
+		}
+	}
+	return nil
+}
+
+// AnalyzerRule struct, adds, overrides or removes a rule of the commit format by tag
+type AnalyzerRule struct {
+	Tag       string `yaml:"tag"`
+	TagString string `yaml:"tagString,omitempty"`
+	Release   string `yaml:"release,omitempty"`
+	Changelog *bool  `yaml:"changelog,omitempty"`
+	Remove    bool   `yaml:"remove,omitempty"`
 }
 
 // ChangelogConfig struct
@@ -56,6 +99,10 @@ type GitHubProvider struct {
 	CustomURL   string `yaml:"customUrl,omitempty"`
 	AccessToken string
 	TagPrefix   *string `yaml:"tagPrefix,omitempty"`
+	// AlreadyExists mode if a release for the tag exists: skip, fail, update or recreate
+	AlreadyExists string `yaml:"alreadyExists,omitempty"`
+	// Draft release, published with the publish command
+	Draft bool `yaml:"draft,omitempty"`
 }
 
 // GitLabProvider struct
@@ -64,6 +111,39 @@ type GitLabProvider struct {
 	CustomURL   string `yaml:"customUrl,omitempty"`
 	AccessToken string
 	TagPrefix   *string `yaml:"tagPrefix,omitempty"`
+	// Draft release with a future release date, published with the publish command
+	Draft bool `yaml:"draft,omitempty"`
+}
+
+// GiteaProvider struct, used for gitea and forgejo
+type GiteaProvider struct {
+	Repo        string `yaml:"repo"`
+	User        string `yaml:"user"`
+	CustomURL   string `yaml:"customUrl,omitempty"`
+	AccessToken string
+	TagPrefix   *string `yaml:"tagPrefix,omitempty"`
+}
+
+// BitbucketProvider struct, used for bitbucket server and data center.
+// The changelog is the tag message or, if ChangelogFile is set, written to this file on ChangelogBranch
+type BitbucketProvider struct {
+	Project         string `yaml:"project"`
+	Repo            string `yaml:"repo"`
+	CustomURL       string `yaml:"customUrl,omitempty"`
+	AccessToken     string
+	TagPrefix       *string `yaml:"tagPrefix,omitempty"`
+	ChangelogFile   string  `yaml:"changelogFile,omitempty"`
+	ChangelogBranch string  `yaml:"changelogBranch,omitempty"`
+}
+
+// AzureProvider struct, CustomURL is the collection url for azure devops server
+type AzureProvider struct {
+	Organization string `yaml:"organization"`
+	Project      string `yaml:"project"`
+	Repo         string `yaml:"repo"`
+	CustomURL    string `yaml:"customUrl,omitempty"`
+	AccessToken  string
+	TagPrefix    *string `yaml:"tagPrefix,omitempty"`
 }
 
 // GitProvider struct
@@ -73,6 +153,19 @@ type GitProvider struct {
 	Auth      string  `yaml:"auth"`
 	SSH       bool    `yaml:"ssh"`
 	TagPrefix *string `yaml:"tagPrefix,omitempty"`
+	// SSHKey file, the ssh agent is used if not set
+	SSHKey                string `yaml:"sshKey,omitempty"`
+	SSHKeyPassphrase      string `yaml:"sshKeyPassphrase,omitempty"`
+	KnownHosts            string `yaml:"knownHosts,omitempty"`
+	InsecureIgnoreHostKey bool   `yaml:"insecureIgnoreHostKey,omitempty"`
+	Remote                string `yaml:"remote,omitempty"`
+	RemoteURL             string `yaml:"remoteUrl,omitempty"`
+	// SignKeyFile or SignKeyEnv with an armored private OpenPGP key, tags are signed if one is set
+	SignKeyFile       string `yaml:"signKeyFile,omitempty"`
+	SignKeyEnv        string `yaml:"signKeyEnv,omitempty"`
+	SignKeyPassphrase string `yaml:"signKeyPassphrase,omitempty"`
+	// ChangelogInTag adds the changelog to the tag message
+	ChangelogInTag bool `yaml:"changelogInTag,omitempty"`
 }
 
 // Hooks struct
@@ -81,6 +174,24 @@ type Hooks struct {
 	PostRelease []string `yaml:"postRelease"`
 }
 
+// ReleaseCommit struct, the files changed by integrations and the changelog file are committed before the release
+type ReleaseCommit struct {
+	Enabled bool `yaml:"enabled"`
+	// Message template, {{.Version}} is replaced with the new version
+	Message       string `yaml:"message,omitempty"`
+	SkipCI        bool   `yaml:"skipCi,omitempty"`
+	ChangelogFile string `yaml:"changelogFile,omitempty"`
+}
+
+// ReleaseComments struct, referenced issues and merge requests are commented after the release
+type ReleaseComments struct {
+	Enabled bool `yaml:"enabled"`
+	// Message template, {{.Version}} is replaced with the new version and {{.Tag}} with the tag
+	Message string `yaml:"message,omitempty"`
+	// Label added to the issues and merge requests, no label if empty
+	Label string `yaml:"label,omitempty"`
+}
+
 // Checksum struct
 type Checksum struct {
 	Algorithm string `yaml:"algorithm"`
@@ -97,22 +208,91 @@ type IntegrationNPM struct {
 	Path    string `yaml:"path"`
 }
 
+// VersioningConfig struct, scheme is semver (default) or calver with a format like YYYY.0M.MICRO
+type VersioningConfig struct {
+	Scheme string `yaml:"scheme,omitempty"`
+	Format string `yaml:"format,omitempty"`
+}
+
 // ReleaseConfig struct
 type ReleaseConfig struct {
-	CommitFormat   string            `yaml:"commitFormat"`
-	Branch         map[string]string `yaml:"branch"`
-	Analyzer       AnalyzerConfig    `yaml:"analyzer"`
-	Changelog      ChangelogConfig   `yaml:"changelog,omitempty"`
-	Release        string            `yaml:"release,omitempty"`
-	GitHubProvider GitHubProvider    `yaml:"github,omitempty"`
-	GitLabProvider GitLabProvider    `yaml:"gitlab,omitempty"`
-	GitProvider    GitProvider       `yaml:"git,omitempty"`
-	Assets         []Asset           `yaml:"assets"`
-	Checksum       Checksum          `yaml:"checksum,omitempty"`
-	Hooks          Hooks             `yaml:"hooks"`
-	Integrations   Integrations      `yaml:"integrations"`
-	ReleaseTitle   string            `yaml:"title"`
-	IsPreRelease   bool
+	CommitFormat      string            `yaml:"commitFormat"`
+	Branch            map[string]string `yaml:"branch"`
+	Analyzer          AnalyzerConfig    `yaml:"analyzer"`
+	Changelog         ChangelogConfig   `yaml:"changelog,omitempty"`
+	Release           string            `yaml:"release,omitempty"`
+	Releases          []string          `yaml:"releases,omitempty"`
+	ReleasePolicy     string            `yaml:"releasePolicy,omitempty"`
+	GitHubProvider    GitHubProvider    `yaml:"github,omitempty"`
+	GitLabProvider    GitLabProvider    `yaml:"gitlab,omitempty"`
+	GiteaProvider     GiteaProvider     `yaml:"gitea,omitempty"`
+	BitbucketProvider BitbucketProvider `yaml:"bitbucket,omitempty"`
+	AzureProvider     AzureProvider     `yaml:"azure,omitempty"`
+	GitProvider       GitProvider       `yaml:"git,omitempty"`
+	Assets            []Asset           `yaml:"assets"`
+	Checksum          Checksum          `yaml:"checksum,omitempty"`
+	Hooks             Hooks             `yaml:"hooks"`
+	Integrations      Integrations      `yaml:"integrations"`
+	ReleaseTitle      string            `yaml:"title"`
+	// InitialDevelopment keeps releases in 0.y.z, breaking changes increase the minor and features the patch version
+	InitialDevelopment bool             `yaml:"initialDevelopment,omitempty"`
+	Versioning         VersioningConfig `yaml:"versioning,omitempty"`
+	ReleaseCommit      ReleaseCommit    `yaml:"releaseCommit,omitempty"`
+	ReleaseComments    ReleaseComments  `yaml:"releaseComments,omitempty"`
+	IsPreRelease       bool
+}
+
+// GetReleases returns the configured release providers, the first one is the primary
+func (c *ReleaseConfig) GetReleases() []string {
+	if len(c.Releases) > 0 {
+		return c.Releases
+	}
+	if c.Release == "" {
+		return []string{}
+	}
+	return []string{c.Release}
+}
+
+// GetTagPrefix of the primary release provider, default is "v"
+func (c *ReleaseConfig) GetTagPrefix() string {
+	var tagPrefix *string
+	primary := ""
+	if releases := c.GetReleases(); len(releases) > 0 {
+		primary = releases[0]
+	}
+	switch primary {
+	case "github":
+		tagPrefix = c.GitHubProvider.TagPrefix
+	case "gitlab":
+		tagPrefix = c.GitLabProvider.TagPrefix
+	case "gitea":
+		tagPrefix = c.GiteaProvider.TagPrefix
+	case "bitbucket":
+		tagPrefix = c.BitbucketProvider.TagPrefix
+	case "azure":
+		tagPrefix = c.AzureProvider.TagPrefix
+	case "git":
+		tagPrefix = c.GitProvider.TagPrefix
+	}
+	if tagPrefix == nil {
+		return DefaultTagPrefix
+	}
+	return *tagPrefix
+}
+
+// IsDraft returns true if the primary release provider creates draft releases, they are published later
+func (c *ReleaseConfig) IsDraft() bool {
+	releases := c.GetReleases()
+	if len(releases) == 0 {
+		return false
+	}
+	switch releases[0] {
+	case "github":
+		return c.GitHubProvider.Draft
+	case "gitlab":
+		return c.GitLabProvider.Draft
+	}
+	return false
 }
 
 // Read ReleaseConfig
@@ -133,6 +313,7 @@ func Read(configPath string) (*ReleaseConfig, error) {
 	org := *releaseConfig
 
 	releaseConfig.Hooks = Hooks{}
+	releaseConfig.Analyzer.Custom.Regex = ""
 
 	configWithoutHooks, err := yaml.Marshal(releaseConfig)
 	if err != nil {
@@ -146,6 +327,13 @@ func Read(configPath string) (*ReleaseConfig, error) {
 	}
 
 	releaseConfigWithExpanedEnvs.Hooks = org.Hooks
+	releaseConfigWithExpanedEnvs.Analyzer.Custom.Regex = org.Analyzer.Custom.Regex
+
+	if releaseConfigWithExpanedEnvs.CommitFormat == "custom" {
+		if err := releaseConfigWithExpanedEnvs.Analyzer.Custom.Validate(); err != nil {
+			return &ReleaseConfig{}, err
+		}
+	}
 
 	log.Tracef("Found config %+v", releaseConfigWithExpanedEnvs)
 
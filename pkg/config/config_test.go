@@ -104,3 +104,54 @@ github:
 	}, result)
 
 }
+
+func TestReadCustomCommitFormat(t *testing.T) {
+
+	dir, err := ioutil.TempDir("", "prefix")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	completePath := path.Join(dir, ".release.yml")
+	content := []byte(`
+commitFormat: custom
+analyzer:
+  custom:
+    regex: '^\[(?P<type>\w+)\] (?P<scope>[A-Z]+-\d+): (?P<subject>.*)$'
+    breakingChangeKeywords:
+      - INCOMPATIBLE
+`)
+	err = ioutil.WriteFile(completePath, content, 0644)
+	assert.NoError(t, err)
+
+	result, readError := config.Read(completePath)
+	assert.NoError(t, readError)
+	assert.Equal(t, `^\[(?P<type>\w+)\] (?P<scope>[A-Z]+-\d+): (?P<subject>.*)$`, result.Analyzer.Custom.Regex)
+	assert.Equal(t, []string{"INCOMPATIBLE"}, result.Analyzer.Custom.BreakingChangeKeywords)
+
+	for _, regex := range []string{`^\[(?P<type>\w+\]`, `^\[(?P<type>\w+)\] .*`} {
+		err = ioutil.WriteFile(completePath, []byte("commitFormat: custom\nanalyzer:\n  custom:\n    regex: '"+regex+"'\n"), 0644)
+		assert.NoError(t, err)
+
+		_, readError = config.Read(completePath)
+		assert.Errorf(t, readError, "Should give error for regex %s", regex)
+	}
+}
+
+func TestGetTagPrefix(t *testing.T) {
+	empty := ""
+	custom := "api/v"
+
+	assert.Equal(t, "v", (&config.ReleaseConfig{Release: "github"}).GetTagPrefix())
+	assert.Equal(t, "", (&config.ReleaseConfig{Release: "github", GitHubProvider: config.GitHubProvider{TagPrefix: &empty}}).GetTagPrefix())
+	assert.Equal(t, "api/v", (&config.ReleaseConfig{Release: "gitlab", GitLabProvider: config.GitLabProvider{TagPrefix: &custom}}).GetTagPrefix())
+	assert.Equal(t, "api/v", (&config.ReleaseConfig{Release: "git", GitProvider: config.GitProvider{TagPrefix: &custom}}).GetTagPrefix())
+	assert.Equal(t, "api/v", (&config.ReleaseConfig{Release: "gitea", GiteaProvider: config.GiteaProvider{TagPrefix: &custom}}).GetTagPrefix())
+	assert.Equal(t, "v", (&config.ReleaseConfig{Release: "git", GitLabProvider: config.GitLabProvider{TagPrefix: &custom}}).GetTagPrefix())
+	assert.Equal(t, "api/v", (&config.ReleaseConfig{Releases: []string{"gitlab", "github"}, GitLabProvider: config.GitLabProvider{TagPrefix: &custom}}).GetTagPrefix())
+}
+
+func TestGetReleases(t *testing.T) {
+	assert.Equal(t, []string{}, (&config.ReleaseConfig{}).GetReleases())
+	assert.Equal(t, []string{"github"}, (&config.ReleaseConfig{Release: "github"}).GetReleases())
+	assert.Equal(t, []string{"github", "gitlab"}, (&config.ReleaseConfig{Release: "git", Releases: []string{"github", "gitlab"}}).GetReleases())
+}
@@ -0,0 +1,79 @@
+package semanticrelease
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// dryRunReport of everything a release would do
+type dryRunReport struct {
+	version      *shared.ReleaseVersion
+	changelog    *shared.GeneratedChangelog
+	integrations string
+	preRelease   []string
+	postRelease  []string
+	assets       *assets.Set
+	releasers    []string
+	calls        map[string]*util.DryRun
+}
+
+// write the report, checksums of the assets are calculated with the given algorithm
+func (r *dryRunReport) write(writer io.Writer, algorithm string) error {
+	w := bufio.NewWriter(writer)
+
+	fmt.Fprintf(w, "Dry run, nothing was changed or released\n\n")
+	fmt.Fprintf(w, "Version: %s -> %s (branch %s)\n\n", r.version.Last.String(), r.version.Next.String(), r.version.Branch)
+
+	fmt.Fprintf(w, "Changelog:\n%s\n%s\n\n", r.changelog.Title, strings.TrimSpace(r.changelog.Content))
+
+	fmt.Fprintf(w, "Integrations:\n")
+	if r.integrations == "" {
+		fmt.Fprintf(w, "  no changes\n")
+	} else {
+		fmt.Fprintf(w, "%s\n", strings.TrimSpace(r.integrations))
+	}
+	fmt.Fprintln(w)
+
+	writeList(w, "Pre release hooks (not run)", r.preRelease)
+	writeList(w, "Post release hooks (not run)", r.postRelease)
+
+	if algorithm == "" {
+		algorithm = "sha256"
+	}
+	assetLines := []string{}
+	for _, asset := range r.assets.All() {
+		checksum, err := asset.GetChecksum()
+		if err != nil {
+			return err
+		}
+		assetLines = append(assetLines, fmt.Sprintf("%s %s", checksum, asset.GetName()))
+	}
+	writeList(w, fmt.Sprintf("Assets (%s)", algorithm), assetLines)
+
+	for _, name := range r.releasers {
+		calls := []string{}
+		if dryRun, ok := r.calls[name]; ok {
+			calls = dryRun.Calls()
+		}
+		writeList(w, fmt.Sprintf("Releaser %s", name), calls)
+	}
+
+	return w.Flush()
+}
+
+func writeList(w io.Writer, title string, lines []string) {
+	fmt.Fprintf(w, "%s:\n", title)
+	if len(lines) == 0 {
+		fmt.Fprintf(w, "  none\n")
+	}
+	for _, line := range lines {
+		fmt.Fprintf(w, "  %s\n", strings.ReplaceAll(line, "\n", "\n    "))
+	}
+	fmt.Fprintln(w)
+}
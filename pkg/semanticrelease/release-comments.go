@@ -0,0 +1,110 @@
+package semanticrelease
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/Masterminds/semver"
+	"github.com/go-git/go-git/v5/plumbing"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/Nightapes/go-semantic-release/internal/analyzer"
+	"github.com/Nightapes/go-semantic-release/internal/cache"
+	"github.com/Nightapes/go-semantic-release/internal/releaser"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// defaultReleaseCommentMessage if no message template is configured
+const defaultReleaseCommentMessage = "Released in {{.Tag}}"
+
+// createComments on the issues and merge requests referenced by the commits of the release
+func (s *SemanticRelease) createComments(r releaser.Releaser, releaseVersion *shared.ReleaseVersion) error {
+	commenter, ok := r.(releaser.Commenter)
+	if !ok {
+		log.Warnf("Comments are not supported by releaser %s, skip comments", strings.Join(s.config.GetReleases(), ", "))
+		return nil
+	}
+
+	references := analyzer.References(releaseVersion.Commits)
+	if len(references) == 0 {
+		log.Infof("No issues or merge requests referenced, skip comments")
+		return nil
+	}
+
+	message, err := s.releaseCommentMessage(releaseVersion)
+	if err != nil {
+		return err
+	}
+
+	return commenter.CreateComments(releaseVersion, &shared.ReleaseComments{
+		Message:    message,
+		Label:      s.config.ReleaseComments.Label,
+		References: references,
+	})
+}
+
+// releasedCommits of the published version from the .version cache, otherwise from the commits of the version
+func (s *SemanticRelease) releasedCommits(releaseVersion *shared.ReleaseVersion) (map[shared.Release][]shared.AnalyzedCommit, error) {
+	cached, err := cache.Read(s.repository)
+	if err != nil {
+		log.Debugf("Could not read cache: %s", err.Error())
+	} else if cached.Next.Version != nil && cached.Next.Version.Equal(releaseVersion.Next.Version) && len(cached.Commits) > 0 {
+		return cached.Commits, nil
+	}
+
+	commits, err := s.commitsOfVersion(releaseVersion.Next.Version, releaseVersion.Next.Commit)
+	if err != nil {
+		return nil, err
+	}
+	return s.analyzer.Analyze(commits), nil
+}
+
+// commitsOfVersion since the previous version till the tag of the version, or till the commit of the release if the tag does not exist yet like for github drafts
+func (s *SemanticRelease) commitsOfVersion(version *semver.Version, commit string) ([]shared.Commit, error) {
+	versions, err := s.gitUtil.GetVersions()
+	if err != nil {
+		return nil, err
+	}
+
+	var lastVersionHash *plumbing.Reference
+	for _, v := range versions {
+		if v.LessThan(version) {
+			if _, lastVersionHash, err = s.gitUtil.GetVersion(v.Original()); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	if _, tag, err := s.gitUtil.GetVersion(version.Original()); err == nil {
+		return s.gitUtil.GetCommitsOfTag(tag, lastVersionHash)
+	}
+	if commit == "" {
+		return nil, fmt.Errorf("could not find the commit of version %s", version.String())
+	}
+	return s.gitUtil.GetCommitsTill(commit, lastVersionHash)
+}
+
+func (s *SemanticRelease) releaseCommentMessage(releaseVersion *shared.ReleaseVersion) (string, error) {
+	text := s.config.ReleaseComments.Message
+	if text == "" {
+		text = defaultReleaseCommentMessage
+	}
+
+	tpl, err := template.New("releaseComment").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse release comment message: %w", err)
+	}
+
+	var message bytes.Buffer
+	err = tpl.Execute(&message, struct{ Version, Tag string }{
+		Version: releaseVersion.Next.String(),
+		Tag:     s.config.GetTagPrefix() + releaseVersion.Next.String(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("could not create release comment message: %w", err)
+	}
+	return message.String(), nil
+}
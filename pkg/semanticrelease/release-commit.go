@@ -0,0 +1,93 @@
+package semanticrelease
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/Nightapes/go-semantic-release/internal/integrations"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
+)
+
+// defaultReleaseCommitMessage if no message template is configured
+const defaultReleaseCommitMessage = "chore(release): {{.Version}}"
+
+// releaseCommit with the files changed by the integrations and the changelog file, nothing is written
+func (s *SemanticRelease) releaseCommit(releaseVersion *shared.ReleaseVersion, generatedChangelog *shared.GeneratedChangelog, integrations *integrations.Integrations) (*shared.ReleaseCommit, error) {
+	message, err := s.releaseCommitMessage(releaseVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	changedFiles, err := integrations.Files()
+	if err != nil {
+		return nil, err
+	}
+
+	files := []shared.ReleaseFile{}
+	for _, file := range changedFiles {
+		path, err := s.repositoryPath(file.Path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, shared.ReleaseFile{Path: path, Content: file.Content})
+	}
+
+	if s.config.ReleaseCommit.ChangelogFile != "" {
+		content := []byte(generatedChangelog.Content)
+		currentContent, err := os.ReadFile(filepath.Join(s.repository, s.config.ReleaseCommit.ChangelogFile))
+		if err == nil {
+			content = append(content, []byte("\n---\n\n")...)
+			content = append(content, currentContent...)
+		} else if !os.IsNotExist(err) {
+			return nil, err
+		}
+		files = append(files, shared.ReleaseFile{Path: filepath.ToSlash(filepath.Clean(s.config.ReleaseCommit.ChangelogFile)), Content: content})
+	}
+
+	return &shared.ReleaseCommit{
+		Message: message,
+		Files:   files,
+	}, nil
+}
+
+func (s *SemanticRelease) releaseCommitMessage(releaseVersion *shared.ReleaseVersion) (string, error) {
+	text := s.config.ReleaseCommit.Message
+	if text == "" {
+		text = defaultReleaseCommitMessage
+	}
+
+	tpl, err := template.New("releaseCommit").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("could not parse release commit message: %w", err)
+	}
+
+	var message bytes.Buffer
+	if err := tpl.Execute(&message, struct{ Version string }{releaseVersion.Next.String()}); err != nil {
+		return "", fmt.Errorf("could not create release commit message: %w", err)
+	}
+
+	if s.config.ReleaseCommit.SkipCI {
+		message.WriteString(" [skip ci]")
+	}
+	return message.String(), nil
+}
+
+// repositoryPath of the file relative to the repository
+func (s *SemanticRelease) repositoryPath(path string) (string, error) {
+	repository, err := filepath.Abs(s.repository)
+	if err != nil {
+		return "", err
+	}
+	file, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	relative, err := filepath.Rel(repository, file)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(relative), nil
+}
@@ -15,13 +15,16 @@ import (
 
 	"github.com/Nightapes/go-semantic-release/internal/analyzer"
 	"github.com/Nightapes/go-semantic-release/internal/assets"
+	"github.com/Nightapes/go-semantic-release/internal/branch"
 	"github.com/Nightapes/go-semantic-release/internal/cache"
 	"github.com/Nightapes/go-semantic-release/internal/calculator"
 	"github.com/Nightapes/go-semantic-release/internal/changelog"
 	"github.com/Nightapes/go-semantic-release/internal/ci"
 	"github.com/Nightapes/go-semantic-release/internal/gitutil"
 	"github.com/Nightapes/go-semantic-release/internal/hooks"
+	"github.com/Nightapes/go-semantic-release/internal/journal"
 	"github.com/Nightapes/go-semantic-release/internal/releaser"
+	"github.com/Nightapes/go-semantic-release/internal/releaser/util"
 	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
 )
@@ -40,7 +43,7 @@ type SemanticRelease struct {
 
 // New SemanticRelease struct
 func New(c *config.ReleaseConfig, repository string, checkConfig bool) (*SemanticRelease, error) {
-	util, err := gitutil.New(repository)
+	util, err := gitutil.New(repository, c.GetTagPrefix())
 	if err != nil {
 		return nil, err
 	}
@@ -54,6 +57,11 @@ func New(c *config.ReleaseConfig, repository string, checkConfig bool) (*Semanti
 		log.Infof("Ignore config checks!. No guarantee to run without issues")
 	}
 
+	calculator, err := calculator.New(c, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
 	assets := assets.New(repository, c.Checksum.Algorithm)
 
 	releaser, err := releaser.New(c, util).GetReleaser(checkConfig)
@@ -69,7 +77,7 @@ func New(c *config.ReleaseConfig, repository string, checkConfig bool) (*Semanti
 		repository:  repository,
 		assets:      assets,
 		checkConfig: checkConfig,
-		calculator:  calculator.New(),
+		calculator:  calculator,
 	}, nil
 }
 
@@ -80,6 +88,11 @@ func (s *SemanticRelease) GetCIProvider() (*ci.ProviderConfig, error) {
 
 // GetNextVersion from .version or calculate new from commits
 func (s *SemanticRelease) GetNextVersion(provider *ci.ProviderConfig, force bool, from string) (*shared.ReleaseVersion, error) {
+	return s.getNextVersion(provider, force, from, true)
+}
+
+// getNextVersion writes the calculated version to .version if writeCache is true
+func (s *SemanticRelease) getNextVersion(provider *ci.ProviderConfig, force bool, from string, writeCache bool) (*shared.ReleaseVersion, error) {
 	log.Debugf("Ignore .version file if exits, %t", force)
 	if !force && from == "" {
 		releaseVersion, err := cache.Read(s.repository)
@@ -111,7 +124,7 @@ func (s *SemanticRelease) GetNextVersion(provider *ci.ProviderConfig, force bool
 	firstRelease := false
 
 	if lastVersion == nil {
-		lastVersion, _ = semver.NewVersion("1.0.0")
+		lastVersion = s.calculator.FirstVersion()
 		log.Infof("This is the first release, will set version to %s", lastVersion.String())
 		firstRelease = true
 	}
@@ -125,30 +138,45 @@ func (s *SemanticRelease) GetNextVersion(provider *ci.ProviderConfig, force bool
 
 	analyzedCommits := s.analyzer.Analyze(commits)
 
-	var newVersion semver.Version
-	foundBranchConfig := false
-	for branch, releaseType := range s.config.Branch {
-		if provider.Branch == branch {
-			log.Debugf("Found branch config for branch %s with release type %s", provider.Branch, releaseType)
-			newVersion = s.calculator.CalculateNewVersion(analyzedCommits, lastVersion, releaseType, firstRelease)
-			foundBranchConfig = true
-			break
-		}
+	versions, err := s.gitUtil.GetVersions()
+	if err != nil {
+		return nil, err
 	}
+	s.calculator.SetVersions(versions)
 
-	if !foundBranchConfig {
+	var newVersion semver.Version
+	if match, ok := branch.Find(s.config.Branch, provider.Branch); ok {
+		log.Debugf("Found branch config %s for branch %s with release type %s", match.Pattern, provider.Branch, match.ReleaseType)
+		if match.ReleaseType == calculator.SNAPSHOT {
+			newVersion, err = s.calculator.CalculateNewVersion(analyzedCommits, lastVersion, "release", provider.Branch, firstRelease)
+			if err == nil {
+				last := *lastVersion
+				if firstRelease {
+					last = *semver.MustParse("0.0.0")
+				}
+				newVersion, err = s.calculator.Snapshot(newVersion, last, len(commits), provider.Commit)
+			}
+		} else {
+			newVersion, err = s.calculator.CalculateNewVersion(analyzedCommits, lastVersion, match.ReleaseType, provider.Branch, firstRelease)
+		}
+		if err != nil {
+			return nil, err
+		}
+	} else {
 		log.Warnf("No branch config found for branch %s, will return last known version", provider.Branch)
 		newVersion = *lastVersion
 	}
 
 	releaseVersion := shared.ReleaseVersion{
 		Next: shared.ReleaseVersionEntry{
-			Commit:  provider.Commit,
-			Version: &newVersion,
+			Commit:        provider.Commit,
+			Version:       &newVersion,
+			VersionString: s.calculator.FormatVersion(&newVersion),
 		},
 		Last: shared.ReleaseVersionEntry{
-			Commit:  "",
-			Version: lastVersion,
+			Commit:        "",
+			Version:       lastVersion,
+			VersionString: s.calculator.FormatVersion(lastVersion),
 		},
 		Branch:  provider.Branch,
 		Commits: analyzedCommits,
@@ -159,9 +187,13 @@ func (s *SemanticRelease) GetNextVersion(provider *ci.ProviderConfig, force bool
 
 	if firstRelease {
 		releaseVersion.Last.Version, _ = semver.NewVersion("0.0.0")
+		releaseVersion.Last.VersionString = ""
 	}
 
-	log.Infof("New version %s -> %s", lastVersion.String(), newVersion.String())
+	log.Infof("New version %s -> %s", s.calculator.FormatVersion(lastVersion), releaseVersion.Next.String())
+	if !writeCache {
+		return &releaseVersion, nil
+	}
 	err = cache.Write(s.repository, releaseVersion)
 	if err != nil {
 		return nil, err
@@ -182,29 +214,43 @@ func (s *SemanticRelease) SetVersion(provider *ci.ProviderConfig, version string
 	}
 	if lastVersion == nil {
 		lastVersion, _ = semver.NewVersion("1.0.0")
+		if s.config.InitialDevelopment {
+			lastVersion, _ = semver.NewVersion("0.0.0")
+		}
+	}
+
+	if s.calculator.IsInitialDevelopment(lastVersion) && newVersion.Major() > 0 {
+		log.Infof("Version %s ends the initial development, breaking changes will increase the major version from now on", newVersion.String())
 	}
 
-	return cache.Write(s.repository, shared.ReleaseVersion{
+	releaseVersion := shared.ReleaseVersion{
 		Next: shared.ReleaseVersionEntry{
-			Commit:  provider.Commit,
-			Version: newVersion,
+			Commit:        provider.Commit,
+			Version:       newVersion,
+			VersionString: s.calculator.FormatVersion(newVersion),
 		},
 		Last: shared.ReleaseVersionEntry{
-			Commit:  lastVersionHash.Hash().String(),
+			Commit:  "",
 			Version: lastVersion,
 		},
 		Branch: provider.Branch,
-	})
+	}
+	if lastVersionHash != nil {
+		releaseVersion.Last.Commit = lastVersionHash.Hash().String()
+		releaseVersion.Last.VersionString = s.calculator.FormatVersion(lastVersion)
+	}
+
+	return cache.Write(s.repository, releaseVersion)
 }
 
 // GetChangelog from last version till now
 func (s *SemanticRelease) GetChangelog(releaseVersion *shared.ReleaseVersion) (*shared.GeneratedChangelog, error) {
 	c := changelog.New(s.config, s.analyzer.GetRules(), time.Now())
 	return c.GenerateChangelog(shared.ChangelogTemplateConfig{
-		Version:    releaseVersion.Next.Version.String(),
+		Version:    releaseVersion.Next.String(),
 		Hash:       releaseVersion.Last.Commit,
 		CommitURL:  s.releaser.GetCommitURL(),
-		CompareURL: s.releaser.GetCompareURL(releaseVersion.Last.Version.String(), releaseVersion.Next.Version.String()),
+		CompareURL: s.releaser.GetCompareURL(releaseVersion.Last.String(), releaseVersion.Next.String()),
 	}, releaseVersion.Commits)
 }
 
@@ -287,29 +333,45 @@ func prependToFile(newChangelogContent, file string) error {
 }
 
 // Release publish release to provider
-func (s *SemanticRelease) Release(provider *ci.ProviderConfig, force bool) error {
+func (s *SemanticRelease) Release(provider *ci.ProviderConfig, force, dryRun bool) error {
 	if provider.IsPR {
 		log.Infof("Will not perform a new release. This is a pull request")
 		return nil
 	}
 
-	if _, ok := s.config.Branch[provider.Branch]; !ok {
+	match, ok := branch.Find(s.config.Branch, provider.Branch)
+	if !ok {
 		log.Infof("Will not perform a new release. Current %s branch is not configured in release config", provider.Branch)
 		return nil
 	}
 
+	if match.ReleaseType == calculator.SNAPSHOT {
+		log.Infof("Will not perform a new release. Snapshot versions of branch %s are never published", provider.Branch)
+		return nil
+	}
+
+	r := s.releaser
+	var dryRunCalls map[string]*util.DryRun
+	if dryRun {
+		var err error
+		r, dryRunCalls, err = releaser.New(s.config, s.gitUtil).GetDryRunReleaser(s.checkConfig)
+		if err != nil {
+			return err
+		}
+	}
+
 	if err := s.assets.Add(s.config.Assets...); err != nil {
 		return err
 	}
 
-	releaseVersion, err := s.GetNextVersion(provider, force, "")
+	releaseVersion, err := s.getNextVersion(provider, force, "", !dryRun)
 	if err != nil {
 		log.Debugf("Could not get next version")
 		return err
 	}
 
 	if releaseVersion.Next.Version.Equal(releaseVersion.Last.Version) {
-		log.Infof("No new version, no release needed %s <> %s", releaseVersion.Next.Version.String(), releaseVersion.Last.Version.String())
+		log.Infof("No new version, no release needed %s <> %s", releaseVersion.Next.String(), releaseVersion.Last.String())
 		return nil
 	}
 
@@ -319,37 +381,115 @@ func (s *SemanticRelease) Release(provider *ci.ProviderConfig, force bool) error
 		return err
 	}
 
+	report := &dryRunReport{
+		version:   releaseVersion,
+		changelog: generatedChangelog,
+		assets:    s.assets,
+		releasers: s.config.GetReleases(),
+		calls:     dryRunCalls,
+	}
+
+	// the journal is not read or written in a dry run
+	var releaseJournal *journal.Journal
+	if !dryRun {
+		if releaseJournal, err = journal.Read(s.repository, releaseVersion); err != nil {
+			return err
+		}
+	}
+
 	integrations := integrations.New(&s.config.Integrations, releaseVersion)
-	if err := integrations.Run(); err != nil {
+	if dryRun {
+		if report.integrations, err = integrations.Diff(); err != nil {
+			return err
+		}
+	} else if err := releaseJournal.Run("integrations", integrations.Run); err != nil {
 		log.Debugf("Error during integrations run")
 		return err
 	}
 
 	hook := hooks.New(s.config, releaseVersion)
-	if err := hook.PreRelease(); err != nil {
+	if dryRun {
+		report.preRelease = hook.PreReleaseCommands()
+		report.postRelease = hook.PostReleaseCommands()
+	} else if err := releaseJournal.Run("pre release hooks", hook.PreRelease); err != nil {
 		log.Debugf("Error during pre release hook")
 		return err
 	}
 
-	if s.config.Checksum.Algorithm != "" {
-		if err := s.assets.GenerateChecksum(); err != nil {
+	if s.config.ReleaseCommit.Enabled {
+		committer, ok := r.(releaser.Committer)
+		if !ok {
+			return fmt.Errorf("release commits are not supported by releaser %s", strings.Join(s.config.GetReleases(), ", "))
+		}
+		if releaseJournal.IsDone("release commit") {
+			log.Infof("Resume release on release commit %s", releaseJournal.ReleaseCommit)
+			committer.SetReleaseCommit(releaseVersion, releaseJournal.ReleaseCommit)
+		}
+		err := releaseJournal.Run("release commit", func() error {
+			commit, err := s.releaseCommit(releaseVersion, generatedChangelog, integrations)
+			if err != nil {
+				return err
+			}
+			hash, err := committer.CreateCommit(releaseVersion, commit)
+			if err != nil {
+				return err
+			}
+			return releaseJournal.SetReleaseCommit(hash)
+		})
+		if err != nil {
 			return err
 		}
 	}
 
-	for _, asset := range s.assets.All() {
-		if asset.IsCompressed() {
-			if _, err := asset.ZipFile(); err != nil {
+	if dryRun {
+		// checksums are calculated from the files in the report, nothing is written
+		s.assets.DryRun()
+	} else {
+		if s.config.Checksum.Algorithm != "" {
+			if err := s.assets.GenerateChecksum(); err != nil {
 				return err
 			}
 		}
+
+		for _, asset := range s.assets.All() {
+			if asset.IsCompressed() {
+				if _, err := asset.ZipFile(); err != nil {
+					return err
+				}
+			}
+		}
 	}
 
-	if err = s.releaser.CreateRelease(releaseVersion, generatedChangelog, s.assets); err != nil {
+	createRelease := func() error {
+		return r.CreateRelease(releaseVersion, generatedChangelog, s.assets)
+	}
+	if journaled, ok := r.(releaser.Journaled); ok {
+		// several providers record their releases on their own
+		journaled.SetJournal(releaseJournal)
+		err = createRelease()
+	} else {
+		err = releaseJournal.Run("release", createRelease)
+	}
+	if err != nil {
 		return err
 	}
 
-	if err := hook.PostRelease(); err != nil {
+	if s.config.ReleaseComments.Enabled && s.config.IsDraft() {
+		log.Infof("Release is a draft, comments are created on publish")
+	} else if s.config.ReleaseComments.Enabled {
+		err := releaseJournal.Run("release comments", func() error {
+			return s.createComments(r, releaseVersion)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		return report.write(os.Stdout, s.config.Checksum.Algorithm)
+	}
+
+	if err := releaseJournal.Run("post release hooks", hook.PostRelease); err != nil {
 		log.Debugf("Error during post release hook")
 		return err
 	}
@@ -357,6 +497,35 @@ func (s *SemanticRelease) Release(provider *ci.ProviderConfig, force bool) error
 	return nil
 }
 
+// Publish the draft release of the given version
+func (s *SemanticRelease) Publish(version string) error {
+	publishVersion, err := semver.NewVersion(version)
+	if err != nil {
+		return err
+	}
+
+	releaseVersion := &shared.ReleaseVersion{
+		Next: shared.ReleaseVersionEntry{
+			Version:       publishVersion,
+			VersionString: s.calculator.FormatVersion(publishVersion),
+		},
+	}
+	published, err := s.releaser.PublishRelease(releaseVersion)
+	if err != nil {
+		return err
+	}
+
+	if !published || !s.config.ReleaseComments.Enabled || !s.config.IsDraft() {
+		return nil
+	}
+
+	// comments of draft releases are created once the release is published
+	if releaseVersion.Commits, err = s.releasedCommits(releaseVersion); err != nil {
+		return err
+	}
+	return s.createComments(s.releaser, releaseVersion)
+}
+
 // ZipFiles zip files configured in release config
 func (s *SemanticRelease) ZipFiles() error {
 	assets := assets.New(s.repository, "")
@@ -5,7 +5,20 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Masterminds/semver"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/Nightapes/go-semantic-release/internal/cache"
+	"github.com/Nightapes/go-semantic-release/internal/gitutil"
+	"github.com/Nightapes/go-semantic-release/internal/integrations"
+	"github.com/Nightapes/go-semantic-release/internal/shared"
 	"github.com/Nightapes/go-semantic-release/pkg/config"
 )
 
@@ -81,3 +94,89 @@ func TestSemanticRelease_WriteChangeLog(t *testing.T) {
 		})
 	}
 }
+
+func TestSemanticRelease_releaseCommit(t *testing.T) {
+	repository := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(repository, "CHANGELOG.md"), []byte("# v1.0.0"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(repository, "package.json"), []byte(`{"version":"1.0.0"}`), 0644))
+
+	releaseConfig := &config.ReleaseConfig{
+		ReleaseCommit: config.ReleaseCommit{
+			Enabled:       true,
+			Message:       "release {{.Version}}",
+			SkipCI:        true,
+			ChangelogFile: "CHANGELOG.md",
+		},
+		Integrations: config.Integrations{
+			NPM: config.IntegrationNPM{Enabled: true, Path: filepath.Join(repository, "package.json")},
+		},
+	}
+	releaseVersion := &shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("2.0.0")}}
+
+	s := &SemanticRelease{config: releaseConfig, repository: repository}
+	commit, err := s.releaseCommit(releaseVersion, &shared.GeneratedChangelog{Content: "# v2.0.0"}, integrations.New(&releaseConfig.Integrations, releaseVersion))
+	assert.NoError(t, err)
+	assert.Equal(t, &shared.ReleaseCommit{
+		Message: "release 2.0.0 [skip ci]",
+		Files: []shared.ReleaseFile{
+			{Path: "package.json", Content: []byte(`{"version":"2.0.0"}`)},
+			{Path: "CHANGELOG.md", Content: []byte("# v2.0.0\n---\n\n# v1.0.0")},
+		},
+	}, commit)
+}
+
+func TestSemanticRelease_commitsOfVersion(t *testing.T) {
+	repository, err := git.Init(memory.NewStorage(), memfs.New())
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+	date := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	commit := func(message string) string {
+		date = date.Add(time.Hour)
+		signature := &object.Signature{Name: "ci", Email: "ci@example.com", When: date}
+		hash, err := worktree.Commit(message, &git.CommitOptions{AllowEmptyCommits: true, Author: signature, Committer: signature})
+		assert.NoError(t, err)
+		return hash.String()
+	}
+
+	first := commit("feat: first")
+	_, err = repository.CreateTag("v1.0.0", plumbing.NewHash(first), nil)
+	assert.NoError(t, err)
+	second := commit("fix: second\n\nCloses #3")
+
+	s := &SemanticRelease{gitUtil: &gitutil.GitUtil{Repository: repository, TagPrefix: "v"}}
+
+	// the tag of a github draft is created on publish, unreleased commits of HEAD are ignored
+	commit("fix: unreleased")
+	commits, err := s.commitsOfVersion(semver.MustParse("1.0.1"), second)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, second, commits[0].Hash)
+
+	_, err = s.commitsOfVersion(semver.MustParse("1.0.1"), "")
+	assert.Error(t, err)
+
+	_, err = repository.CreateTag("v1.0.1", plumbing.NewHash(second), nil)
+	assert.NoError(t, err)
+	commits, err = s.commitsOfVersion(semver.MustParse("1.0.1"), "")
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	assert.Equal(t, second, commits[0].Hash)
+}
+
+func TestSemanticRelease_releasedCommits(t *testing.T) {
+	repository := filepath.Join(t.TempDir(), "repo")
+	commits := map[shared.Release][]shared.AnalyzedCommit{
+		"patch": {{Commit: shared.Commit{Message: "fix: cached\n\nCloses #3", Hash: "abc"}, Subject: "cached"}},
+	}
+	assert.NoError(t, cache.Write(repository, shared.ReleaseVersion{
+		Next:    shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.1")},
+		Last:    shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.0")},
+		Commits: commits,
+	}))
+
+	s := &SemanticRelease{repository: repository}
+	released, err := s.releasedCommits(&shared.ReleaseVersion{Next: shared.ReleaseVersionEntry{Version: semver.MustParse("1.0.1")}})
+	assert.NoError(t, err)
+	assert.Equal(t, commits["patch"][0].Commit.Hash, released["patch"][0].Commit.Hash)
+}